@@ -0,0 +1,107 @@
+package semver
+
+import "fmt"
+
+/*
+Builder constructs a Version fluently from its parts, validating each part as
+it is set, for example:
+
+v, err := NewBuilder().Major(1).Minor(2).Patch(3).PreRelease("rc.1").Build()
+
+The first invalid part is kept as an error and returned by Build, and any
+parts set after it are ignored.
+*/
+type Builder struct {
+	v   Version
+	err error
+}
+
+/*
+NewBuilder returns a Builder for the version v0.0.0 with the config, or the
+default config if none is passed, so the built version compares and writes
+like a parsed one.
+*/
+func NewBuilder(conf ...*config) *Builder {
+	return &Builder{v: Version{config: getConfig(conf)}}
+}
+
+// Major sets the major version number.
+func (b *Builder) Major(major uint64) *Builder {
+	if b.err != nil {
+		return b
+	}
+	b.v.major = major
+	return b
+}
+
+// Minor sets the minor version number.
+func (b *Builder) Minor(minor uint64) *Builder {
+	if b.err != nil {
+		return b
+	}
+	b.v.minor = minor
+	return b
+}
+
+// Patch sets the patch version number.
+func (b *Builder) Patch(patch uint64) *Builder {
+	if b.err != nil {
+		return b
+	}
+	b.v.patch = patch
+	return b
+}
+
+/*
+PreRelease sets the pre release, which must be a dot separated list of
+alphanumeric or hyphen identifiers, with no empty identifiers or leading zeros
+in numeric identifiers, as with Version.SetPreRelease. An empty pre clears the
+pre release.
+*/
+func (b *Builder) PreRelease(pre string) *Builder {
+	if b.err != nil {
+		return b
+	}
+	if pre != "" {
+		if err := validateIdentifiers(pre, true); err != nil {
+			b.err = fmt.Errorf("invalid pre release %q: %w", pre, err)
+			return b
+		}
+	}
+
+	b.v.preRelease = pre
+	b.v.preReleaseParts = splitIdentifiers(pre)
+	return b
+}
+
+/*
+Metadata sets the build metadata, which must be a dot separated list of
+alphanumeric or hyphen identifiers with no empty identifiers, as with
+Version.SetMetadata. An empty meta clears the build metadata.
+*/
+func (b *Builder) Metadata(meta string) *Builder {
+	if b.err != nil {
+		return b
+	}
+	if meta != "" {
+		if err := validateIdentifiers(meta, false); err != nil {
+			b.err = fmt.Errorf("invalid build metadata %q: %w", meta, err)
+			return b
+		}
+	}
+
+	b.v.buildMetadata = meta
+	return b
+}
+
+/*
+Build returns a new Version from the parts set on the Builder, or the error of
+the first invalid part. The Builder can be reused, and later changes do not
+affect a returned Version.
+*/
+func (b *Builder) Build() (*Version, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.v.Clone(), nil
+}
@@ -0,0 +1,73 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/franela/goblin"
+)
+
+func TestBuilder(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version builder", func() {
+		g.It("Should build a full version", func() {
+			v, err := NewBuilder().Major(1).Minor(2).Patch(3).PreRelease("rc.1").Metadata("build.5").Build()
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.2.3-rc.1+build.5")
+			g.Assert(v.PreReleaseIdentifiers()).Equal([]string{"rc", "1"})
+			g.Assert(v.Compare(String("v1.2.3-rc.1").Get())).Equal(0)
+			g.Assert(v.Compare(String("v1.2.3-rc.2").Get())).Equal(-1)
+		})
+
+		g.It("Should build v0.0.0 with no parts", func() {
+			v, err := NewBuilder().Build()
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v0.0.0")
+		})
+
+		g.It("Should build with a config", func() {
+			v, err := NewBuilder(DefaultConfig(WithPrefix(""))).Major(2).Build()
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("2.0.0")
+		})
+
+		g.It("Should return an error for an invalid pre release", func() {
+			v, err := NewBuilder().Major(1).PreRelease("rc.01").Metadata("build").Build()
+			g.Assert(v == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`invalid pre release "rc.01": identifier "01" has a leading zero`)
+			g.Assert(errors.Is(err, ErrLeadingZero)).IsTrue()
+
+			_, err = NewBuilder().PreRelease("rc..1").Build()
+			g.Assert(errors.Is(err, ErrEmptyIdentifier)).IsTrue()
+		})
+
+		g.It("Should keep the first error", func() {
+			_, err := NewBuilder().Metadata("a_b").PreRelease("rc.01").Build()
+			g.Assert(err.Error()).Equal(`invalid build metadata "a_b": identifier "a_b" contains invalid character '_'`)
+		})
+
+		g.It("Should ignore parts set after an invalid part", func() {
+			b := NewBuilder().PreRelease("rc.01").Major(1).Minor(2).Patch(3)
+			g.Assert(b.v.String()).Equal("v0.0.0")
+		})
+
+		g.It("Should not change a built version when reused", func() {
+			b := NewBuilder().Major(1).PreRelease("alpha")
+			v, _ := b.Build()
+			w, _ := b.Minor(1).PreRelease("").Build()
+			g.Assert(v.String()).Equal("v1.0.0-alpha")
+			g.Assert(w.String()).Equal("v1.1.0")
+		})
+	})
+}
+
+func ExampleBuilder() {
+	v, err := NewBuilder().Major(1).Minor(2).Patch(3).PreRelease("rc.1").Metadata("build.5").Build()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(v)
+	// Output: v1.2.3-rc.1+build.5
+}
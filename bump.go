@@ -0,0 +1,243 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+Clone returns a copy of the version which can be modified independently of
+the original. The clone shares the config of the original, which is never
+modified after it is created.
+*/
+func (v *Version) Clone() *Version {
+	c := *v
+	return &c
+}
+
+/*
+WithoutOperator returns a copy of the version with the Operator cleared, for
+display or further comparison of the underlying version. The original version
+is not modified.
+*/
+func (v *Version) WithoutOperator() *Version {
+	c := v.Clone()
+	c.operator = ""
+	return c
+}
+
+/*
+IncMajor returns a new Version with the major version incremented, and the
+minor and patch versions reset to zero. Pre release and build metadata are
+cleared.
+*/
+func (v *Version) IncMajor() *Version {
+	return &Version{
+		operator: v.operator,
+		epoch:    v.epoch,
+		major:    v.major + 1,
+
+		inputPrefix: v.inputPrefix,
+		keepPrefix:  v.keepPrefix,
+		config:      v.config,
+	}
+}
+
+/*
+IncMinor returns a new Version with the minor version incremented, and the
+patch version reset to zero. Pre release and build metadata are cleared.
+*/
+func (v *Version) IncMinor() *Version {
+	return &Version{
+		operator: v.operator,
+		epoch:    v.epoch,
+		major:    v.major,
+		minor:    v.minor + 1,
+
+		inputPrefix: v.inputPrefix,
+		keepPrefix:  v.keepPrefix,
+		config:      v.config,
+	}
+}
+
+/*
+IncPatch returns a new Version with the patch version incremented. Pre
+release and build metadata are cleared.
+
+A pre release version is instead promoted to the release of the same version,
+as a pre release has lower precedence than its release. For example,
+v1.2.3-rc.1 increments to v1.2.3 rather than v1.2.4. A promoted pre release
+keeps any WithBuildNumber build number, so 1.2.3.4-rc promotes to 1.2.3.4,
+while incrementing a release resets it to zero.
+*/
+func (v *Version) IncPatch() *Version {
+	patch, build := v.patch, v.build
+	if v.preRelease == "" {
+		patch++
+		build = 0
+	}
+
+	return &Version{
+		operator: v.operator,
+		epoch:    v.epoch,
+		major:    v.major,
+		minor:    v.minor,
+		patch:    patch,
+		build:    build,
+
+		inputPrefix: v.inputPrefix,
+		keepPrefix:  v.keepPrefix,
+		config:      v.config,
+	}
+}
+
+/*
+FinalizeRelease returns a new Version for the release of the version, with the
+pre release and build metadata cleared, so v1.2.3-rc.1 finalizes to v1.2.3.
+A version which is already a release keeps its version numbers, so v1.2.3
+finalizes to v1.2.3. The Operator is kept.
+
+This differs from IncPatch, which also promotes a pre release to its release,
+but increments the patch version of a release, so v1.2.3 increments to v1.2.4.
+*/
+func (v *Version) FinalizeRelease() *Version {
+	c := v.Core()
+	c.operator = v.operator
+	return c
+}
+
+/*
+SetPreRelease returns a copy of the version with the pre release set to pre,
+which must be a dot separated list of alphanumeric or hyphen identifiers, with
+no empty identifiers or leading zeros in numeric identifiers. An empty pre
+clears the pre release. The original version is not modified.
+*/
+func (v *Version) SetPreRelease(pre string) (*Version, error) {
+	if pre != "" {
+		if err := validateIdentifiers(pre, true); err != nil {
+			return nil, fmt.Errorf("invalid pre release %q: %w", pre, err)
+		}
+	}
+
+	c := v.Clone()
+	c.preRelease = pre
+	c.preReleaseParts = splitIdentifiers(pre)
+	return c, nil
+}
+
+/*
+IncPreRelease returns a copy of the version with the trailing numeric
+identifier of the pre release incremented, so v1.2.3-rc.1 increments to
+v1.2.3-rc.2. When the last identifier is not numeric a .1 identifier is
+appended, so v1.2.3-beta increments to v1.2.3-beta.1. Build metadata is
+cleared.
+
+An error is returned if the version has no pre release, or the trailing
+identifier would overflow a uint64.
+*/
+func (v *Version) IncPreRelease() (*Version, error) {
+	if v.preRelease == "" {
+		return nil, fmt.Errorf("cannot increment pre release of %q: no pre release", v.String())
+	}
+
+	ids := v.PreReleaseIdentifiers()
+	last := ids[len(ids)-1]
+	if isNumeric(last) {
+		n, err := strconv.ParseUint(last, 10, 64)
+		if err != nil || n == ^uint64(0) {
+			return nil, fmt.Errorf("cannot increment pre release of %q: identifier %q overflows", v.String(), last)
+		}
+		ids[len(ids)-1] = strconv.FormatUint(n+1, 10)
+	} else {
+		ids = append(ids, "1")
+	}
+
+	c := v.Clone()
+	c.preRelease = strings.Join(ids, ".")
+	c.preReleaseParts = ids
+	c.buildMetadata = ""
+	return c, nil
+}
+
+/*
+PromoteChannel returns a copy of the version with the pre release advanced to
+the next release channel of the order, such as alpha, beta, and rc. The
+leading pre release identifier names the current channel, and the pre release
+is replaced with only the next channel, so v1.2.3-alpha.3 promotes to
+v1.2.3-beta. A version on the last channel promotes to its release, so
+v1.2.3-rc promotes to v1.2.3. Build metadata is cleared.
+
+An error is returned if the version has no pre release, the current channel is
+not in the order, or the next channel is not a valid pre release.
+*/
+func (v *Version) PromoteChannel(order []string) (*Version, error) {
+	if v.preRelease == "" {
+		return nil, fmt.Errorf("cannot promote %q: no pre release", v.String())
+	}
+
+	channel := v.PreReleaseIdentifiers()[0]
+	fold := v.conf().foldCase
+	for i, name := range order {
+		if name != channel && !(fold && strings.EqualFold(name, channel)) {
+			continue
+		}
+
+		if i == len(order)-1 {
+			c := v.Clone()
+			c.preRelease = ""
+			c.preReleaseParts = nil
+			c.buildMetadata = ""
+			return c, nil
+		}
+
+		c, err := v.SetPreRelease(order[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("cannot promote %q: %w", v.String(), err)
+		}
+		c.buildMetadata = ""
+		return c, nil
+	}
+
+	return nil, fmt.Errorf("cannot promote %q: channel %q is not in %q", v.String(), channel, order)
+}
+
+/*
+SetMetadata returns a copy of the version with the build metadata set to
+meta, which must be a dot separated list of alphanumeric or hyphen
+identifiers with no empty identifiers. Leading zeros are allowed, as build
+metadata has no numeric identifiers. An empty meta clears the build metadata.
+The original version is not modified.
+
+Build metadata does not affect version precedence with Compare.
+*/
+func (v *Version) SetMetadata(meta string) (*Version, error) {
+	if meta != "" {
+		if err := validateIdentifiers(meta, false); err != nil {
+			return nil, fmt.Errorf("invalid build metadata %q: %w", meta, err)
+		}
+	}
+
+	c := v.Clone()
+	c.buildMetadata = meta
+	return c, nil
+}
+
+/*
+Core returns a copy of the version with only the major, minor, and patch
+version numbers, and any WithEpoch epoch and WithBuildNumber build number. The
+Operator, pre release, and build metadata are cleared.
+*/
+func (v *Version) Core() *Version {
+	return &Version{
+		epoch: v.epoch,
+		major: v.major,
+		minor: v.minor,
+		patch: v.patch,
+		build: v.build,
+
+		inputPrefix: v.inputPrefix,
+		keepPrefix:  v.keepPrefix,
+		config:      v.config,
+	}
+}
@@ -0,0 +1,281 @@
+package semver
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/franela/goblin"
+)
+
+func TestClone(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version clone", func() {
+		g.It("Should return an independent copy", func() {
+			v := String(">=v1.2.3-alpha+build").Get()
+			c := v.Clone()
+			g.Assert(c == v).IsFalse()
+			g.Assert(string(c.ToString())).Equal(">=v1.2.3-alpha+build")
+			g.Assert(c.config == v.config).IsTrue()
+
+			c, err := c.SetPreRelease("beta")
+			g.Assert(err).IsNil()
+			g.Assert(c.PreRelease()).Equal("beta")
+			g.Assert(v.PreRelease()).Equal("alpha")
+		})
+	})
+}
+
+func TestWithoutOperator(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version without operator", func() {
+		g.It("Should clear the operator", func() {
+			v := String(">=v1.2.3").Get()
+			g.Assert(string(v.WithoutOperator().ToString())).Equal("v1.2.3")
+			g.Assert(v.Operator()).Equal(">=")
+		})
+
+		g.It("Should keep the rest of the version", func() {
+			v := String("~v1.2.3-rc.1+build").Get().WithoutOperator()
+			g.Assert(string(v.ToString())).Equal("v1.2.3-rc.1+build")
+			g.Assert(v.OpCompare(String("v1.2.3-rc.1").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.2.4").Get())).IsFalse()
+		})
+	})
+}
+
+func TestInc(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version increments", func() {
+		g.It("Should increment the major version", func() {
+			v := String("v1.2.3-rc.1+build").Get()
+			g.Assert(v.IncMajor().String()).Equal("v2.0.0")
+			g.Assert(v.String()).Equal("v1.2.3-rc.1+build")
+		})
+
+		g.It("Should increment the minor version", func() {
+			v := String("v1.2.3-rc.1+build").Get()
+			g.Assert(v.IncMinor().String()).Equal("v1.3.0")
+		})
+
+		g.It("Should increment the patch version", func() {
+			v := String("v1.2.3+build").Get()
+			g.Assert(v.IncPatch().String()).Equal("v1.2.4")
+		})
+
+		g.It("Should promote a pre release to its release with IncPatch", func() {
+			v := String("v1.2.3-rc.1+build").Get()
+			g.Assert(v.IncPatch().String()).Equal("v1.2.3")
+		})
+
+		g.It("Should keep the build number when IncPatch promotes a pre release", func() {
+			conf := DefaultConfig(WithBuildNumber())
+			g.Assert(String("1.2.3.4-rc").Get(conf).IncPatch().String()).Equal("v1.2.3.4")
+			g.Assert(String("1.2.3.4").Get(conf).IncPatch().String()).Equal("v1.2.4.0")
+		})
+
+		g.It("Should keep the operator", func() {
+			v := String(">=v1.2.3").Get()
+			g.Assert(string(v.IncMinor().ToString())).Equal(">=v1.3.0")
+		})
+	})
+}
+
+func TestFinalizeRelease(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version release finalization", func() {
+		g.It("Should finalize a pre release to its core", func() {
+			v := String("v1.2.3-rc.1+build.5").Get()
+			g.Assert(v.FinalizeRelease().String()).Equal("v1.2.3")
+			g.Assert(v.String()).Equal("v1.2.3-rc.1+build.5")
+			g.Assert(v.FinalizeRelease().Compare(v.IncPatch())).Equal(0)
+		})
+
+		g.It("Should leave a release unchanged", func() {
+			v := String("v1.2.3").Get()
+			g.Assert(v.FinalizeRelease().String()).Equal("v1.2.3")
+			g.Assert(v.IncPatch().String()).Equal("v1.2.4")
+		})
+
+		g.It("Should keep the operator", func() {
+			g.Assert(String(">=v1.2.3-rc.1").Get().FinalizeRelease().ToString()).Equal(String(">=v1.2.3"))
+		})
+	})
+}
+
+func TestSetPreRelease(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version pre release mutation", func() {
+		g.It("Should return a copy with a valid pre release", func() {
+			v := String("v1.2.3+build").Get()
+			pre, err := v.SetPreRelease("rc.1")
+			g.Assert(err).IsNil()
+			g.Assert(pre.String()).Equal("v1.2.3-rc.1+build")
+			g.Assert(v.String()).Equal("v1.2.3+build")
+		})
+
+		g.It("Should clear the pre release", func() {
+			pre, err := String("v1.2.3-rc.1").Get().SetPreRelease("")
+			g.Assert(err).IsNil()
+			g.Assert(pre.String()).Equal("v1.2.3")
+		})
+
+		g.It("Should reject an empty identifier", func() {
+			pre, err := String("v1.2.3").Get().SetPreRelease("a..b")
+			g.Assert(pre == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`invalid pre release "a..b": empty identifier`)
+		})
+
+		g.It("Should reject a numeric identifier with a leading zero", func() {
+			_, err := String("v1.2.3").Get().SetPreRelease("rc.01")
+			g.Assert(err.Error()).Equal(`invalid pre release "rc.01": identifier "01" has a leading zero`)
+		})
+
+		g.It("Should reject invalid characters", func() {
+			_, err := String("v1.2.3").Get().SetPreRelease("rc_1")
+			g.Assert(err.Error()).Equal(`invalid pre release "rc_1": identifier "rc_1" contains invalid character '_'`)
+		})
+	})
+}
+
+func TestIncPreRelease(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version pre release increment", func() {
+		g.It("Should increment a trailing numeric identifier", func() {
+			v, err := String("v1.2.3-rc.1").Get().IncPreRelease()
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.2.3-rc.2")
+			g.Assert(v.PreReleaseIdentifiers()).Equal([]string{"rc", "2"})
+
+			v, err = String("v1.2.3-9").Get().IncPreRelease()
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.2.3-10")
+		})
+
+		g.It("Should append an identifier to a non-numeric pre release", func() {
+			v, err := String("v1.2.3-beta").Get().IncPreRelease()
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.2.3-beta.1")
+			g.Assert(v.GreaterThan(String("v1.2.3-beta").Get())).IsTrue()
+		})
+
+		g.It("Should clear build metadata and keep the original", func() {
+			orig := String(">=v1.2.3-rc.1+build.5").Get()
+			v, err := orig.IncPreRelease()
+			g.Assert(err).IsNil()
+			g.Assert(v.ToString()).Equal(String(">=v1.2.3-rc.2"))
+			g.Assert(orig.ToString()).Equal(String(">=v1.2.3-rc.1+build.5"))
+		})
+
+		g.It("Should return an error without a pre release", func() {
+			v, err := String("v1.2.3").Get().IncPreRelease()
+			g.Assert(v == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`cannot increment pre release of "v1.2.3": no pre release`)
+		})
+
+		g.It("Should return an error on overflow", func() {
+			_, err := String("v1.2.3-rc.18446744073709551615").Get().IncPreRelease()
+			g.Assert(err == nil).IsFalse()
+		})
+	})
+}
+
+func TestPromoteChannel(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Pre release channel promotion", func() {
+		order := []string{"alpha", "beta", "rc"}
+
+		g.It("Should advance to the next channel", func() {
+			v := String(">=v1.2.3-alpha.3+build.7").Get()
+			p, err := v.PromoteChannel(order)
+			g.Assert(err).IsNil()
+			g.Assert(string(p.ToString())).Equal(">=v1.2.3-beta")
+			g.Assert(p.PreReleaseIdentifiers()).Equal([]string{"beta"})
+			g.Assert(v.PreRelease()).Equal("alpha.3")
+
+			p, err = p.PromoteChannel(order)
+			g.Assert(err).IsNil()
+			g.Assert(p.String()).Equal("v1.2.3-rc")
+		})
+
+		g.It("Should promote the last channel to the release", func() {
+			p, err := String("v1.2.3-rc").Get().PromoteChannel(order)
+			g.Assert(err).IsNil()
+			g.Assert(p.String()).Equal("v1.2.3")
+			g.Assert(len(p.PreReleaseIdentifiers())).Equal(0)
+
+			p, err = String("v1.2.3-rc.2").Get().PromoteChannel(order)
+			g.Assert(err).IsNil()
+			g.Assert(p.String()).Equal("v1.2.3")
+		})
+
+		g.It("Should match channels case insensitively with CaseInsensitive", func() {
+			_, err := String("v1.2.3-Beta.1").Get().PromoteChannel(order)
+			g.Assert(err == nil).IsFalse()
+
+			p, err := String("v1.2.3-Beta.1").Get(DefaultConfig(CaseInsensitive())).PromoteChannel(order)
+			g.Assert(err).IsNil()
+			g.Assert(p.String()).Equal("v1.2.3-rc")
+		})
+
+		g.It("Should return an error for a version which cannot be promoted", func() {
+			_, err := String("v1.2.3").Get().PromoteChannel(order)
+			g.Assert(err.Error()).Equal(`cannot promote "v1.2.3": no pre release`)
+
+			_, err = String("v1.2.3-dev.1").Get().PromoteChannel(order)
+			g.Assert(err.Error()).Equal(`cannot promote "v1.2.3-dev.1": channel "dev" is not in ["alpha" "beta" "rc"]`)
+
+			_, err = String("v1.2.3-alpha").Get().PromoteChannel([]string{"alpha", "release candidate"})
+			g.Assert(err.Error()).Equal(`cannot promote "v1.2.3-alpha": invalid pre release "release candidate": identifier "release candidate" contains invalid character ' '`)
+		})
+	})
+}
+
+func TestSetMetadata(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version build metadata mutation", func() {
+		g.It("Should return a copy with valid build metadata", func() {
+			v := String("v1.2.3-rc.1").Get()
+			meta, err := v.SetMetadata("build.007")
+			g.Assert(err).IsNil()
+			g.Assert(meta.Metadata()).Equal("build.007")
+			g.Assert(meta.String()).Equal("v1.2.3-rc.1+build.007")
+			g.Assert(v.Metadata()).Equal("")
+		})
+
+		g.It("Should not affect version precedence", func() {
+			v := String("v1.2.3+a").Get()
+			meta, err := v.SetMetadata("b")
+			g.Assert(err).IsNil()
+			g.Assert(meta.Compare(v)).Equal(0)
+		})
+
+		g.It("Should reject an empty identifier", func() {
+			meta, err := String("v1.2.3").Get().SetMetadata("sha.")
+			g.Assert(meta == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`invalid build metadata "sha.": empty identifier`)
+		})
+	})
+}
+
+func TestCore(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version core", func() {
+		g.It("Should strip the pre release, build metadata, and operator", func() {
+			v := String(">=v1.2.3-rc.1+build").Get()
+			g.Assert(string(v.Core().ToString())).Equal("v1.2.3")
+			g.Assert(string(v.ToString())).Equal(">=v1.2.3-rc.1+build")
+		})
+	})
+}
+
+func ExampleVersion_IncMinor() {
+	v := String("v1.2.3").Get()
+	fmt.Println(v.IncMinor())
+	// Output: v1.3.0
+}
+
+func ExampleVersion_Core() {
+	v := String("v1.2.3-rc.1+build").Get()
+	fmt.Println(v.Core())
+	// Output: v1.2.3
+}
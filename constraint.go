@@ -0,0 +1,541 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+/*
+Constraint is a set of version rules which must be satisfied by a Version.
+Each rule is a Version with an Operator, evaluated with Version.OpCompare.
+*/
+type Constraint struct {
+	// groups are the || separated alternatives of the constraint, of which at
+	// least one must pass. Each group is a set of space separated clauses which
+	// must all pass.
+	groups [][]*Version
+	// config is the config the constraint was parsed with.
+	config *config
+}
+
+/*
+ParseConstraint returns a Constraint from the string s, which is a space
+separated list of versions with an optional Operator. For example:
+
+>=1.2.0 <2.0.0
+
+Every clause must be satisfied for a Version to pass the Constraint.
+
+Alternative sets of clauses can be separated with ||, and a Version passes the
+Constraint if it satisfies any one of the sets. As with npm, the space
+separated clauses bind tighter than ||, so the following allows either a 1.x
+or 3.x version:
+
+>=1.0.0 <2.0.0 || >=3.0.0 <4.0.0
+
+Ranges can be written with x, X, or * wildcards, or as partial versions, where
+1.x is equivalent to >=1.0.0 <2.0.0 and 1.2.* is equivalent to
+>=1.2.0 <1.3.0. A bare *, x, or X, a version of only wildcards such as x.x.x
+or *.*, the literal latest, or an empty or blank constraint string match any
+version. As with any Constraint, pre releases are
+only matched with the IncludePrerelease Option.
+
+Inclusive ranges can be written with the npm hyphen syntax, where
+1.0.0 - 2.0.0 is equivalent to >=1.0.0 <=2.0.0. A partial upper bound matches
+any omitted version numbers, so 1.2.3 - 2.3 is equivalent to >=1.2.3 <2.4.0.
+
+An Operator may be separated from its version by whitespace, as in
+Rubygems style constraints such as ~> 1.2.
+
+An error is returned if any clause is not a valid semantic version, or combines
+an Operator with a wildcard in a way that has no well-defined meaning, such as
+>=1.x or ^*.
+*/
+func ParseConstraint(s string, conf ...*config) (*Constraint, error) {
+	set := getConfig(conf)
+
+	c := &Constraint{config: set}
+	if strings.TrimSpace(s) == "" {
+		// an empty constraint is a single group with no clauses
+		c.groups = [][]*Version{{}}
+		return c, nil
+	}
+
+	for _, group := range strings.Split(s, "||") {
+		clauses, err := parseClauses(group, set)
+		if err != nil {
+			return nil, fmt.Errorf("invalid constraint %q: %w", s, err)
+		}
+		c.groups = append(c.groups, clauses)
+	}
+
+	return c, nil
+}
+
+/*
+ParseConstraints parses each constraint string of the list with
+ParseConstraint, such as a JSON array of constraints loaded from a config
+file. An error is returned for the first malformed constraint.
+*/
+func ParseConstraints(list []string, conf ...*config) ([]*Constraint, error) {
+	constraints := make([]*Constraint, len(list))
+	for i, s := range list {
+		c, err := ParseConstraint(s, conf...)
+		if err != nil {
+			return nil, err
+		}
+		constraints[i] = c
+	}
+	return constraints, nil
+}
+
+/*
+GetConstraint parses the full range expression of the String as a Constraint
+with ParseConstraint, so a String field can carry a constraint such as
+>=1.0.0 <2.0.0 rather than a single version.
+*/
+func (v String) GetConstraint(conf ...*config) (*Constraint, error) {
+	return ParseConstraint(string(v), conf...)
+}
+
+// parseClauses parses a space separated set of constraint clauses, expanding
+// any hyphen ranges.
+func parseClauses(s string, set *config) ([]*Version, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, errors.New("no versions")
+	}
+
+	var versions []*Version
+	for i := 0; i < len(fields); i++ {
+		// a bare wildcard matches any version, and adds no clause
+		if isWildcardVersion(fields[i]) || fields[i] == "latest" {
+			continue
+		}
+
+		clauses := []String{String(fields[i])}
+		start := i
+
+		// join an operator separated from its version by whitespace
+		if i+1 < len(fields) && set.opRe.MatchString(fields[i]) && startsWithVersion(fields[i+1]) {
+			clauses = []String{String(fields[i] + fields[i+1])}
+			i++
+		}
+
+		if err := validateWildcard(clauses[0], set); err != nil {
+			return nil, err
+		}
+
+		// rewrite a hyphen range into an inclusive lower and upper bound
+		if i+2 < len(fields) && fields[i+1] == "-" {
+			if clauseOperator(string(clauses[0]), set) != "" {
+				return nil, fmt.Errorf("invalid clause %q: hyphen ranges cannot have an operator", strings.Join(fields[start:i+3], " "))
+			}
+			if set.ops.GTE == "" || set.ops.LTE == "" {
+				return nil, fmt.Errorf("invalid clause %q: hyphen ranges need the GTE and LTE operators", strings.Join(fields[i:i+3], " "))
+			}
+			clauses = []String{
+				String(string(set.ops.GTE) + fields[i]),
+				String(string(set.ops.LTE) + fields[i+2]),
+			}
+			i += 2
+		}
+
+		for _, clause := range clauses {
+			v, err := clause.parse(set, true, true)
+			if err != nil {
+				return nil, err
+			}
+			if !set.ops.has(v.operator) {
+				return nil, fmt.Errorf("invalid clause %q: unknown operator %q", string(clause), string(v.operator))
+			}
+			versions = append(versions, v)
+		}
+	}
+
+	return versions, nil
+}
+
+// isWildcardVersion returns true if s is a bare wildcard, or a version made of
+// only wildcard version numbers such as x.x.x, which matches any version.
+func isWildcardVersion(s string) bool {
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return false
+	}
+	for _, p := range parts {
+		if !isWildcard(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// startsWithVersion reports whether a constraint field begins with a version,
+// or a wildcard, rather than an Operator.
+func startsWithVersion(s string) bool {
+	if s == "" {
+		return false
+	}
+	return unicode.IsDigit(rune(s[0])) || strings.IndexByte("vxX*", s[0]) >= 0
+}
+
+// clauseOperator returns the longest Operator prefix of a clause matching the
+// config operator regex, or an empty string if the clause has no Operator.
+func clauseOperator(s string, set *config) string {
+	for i := len(s) - 1; i > 0; i-- {
+		if set.opRe.MatchString(s[:i]) {
+			return s[:i]
+		}
+	}
+	return ""
+}
+
+/*
+validateWildcard returns an error for a clause which combines an Operator
+with a wildcard in a way that has no well-defined meaning, such as a bare
+wildcard with any Operator like ^*, or a wildcard version number with a
+comparison Operator like >=1.x. Omitted version numbers, such as >=1, and
+wildcards with the Caret, Tilde, or Pessimistic Operators remain valid.
+*/
+func validateWildcard(clause String, set *config) error {
+	s := string(clause)
+	op := clauseOperator(s, set)
+	if op == "" {
+		return nil
+	}
+
+	rest := s[len(op):]
+	if isWildcardVersion(rest) {
+		return fmt.Errorf("invalid clause %q: operator %q cannot be used with a bare wildcard", s, op)
+	}
+
+	switch Operator(op) {
+	case set.ops.GT, set.ops.GTE, set.ops.LT, set.ops.LTE, set.ops.NEQ:
+		if i := strings.IndexAny(rest, "-+"); i >= 0 {
+			rest = rest[:i]
+		}
+		if strings.ContainsAny(rest, "xX*") {
+			return fmt.Errorf("invalid clause %q: operator %q cannot be used with a wildcard version number", s, op)
+		}
+	}
+	return nil
+}
+
+/*
+String returns the canonical text of the Constraint, which ParseConstraint
+parses back to an equivalent Constraint with the same config. The clauses of
+each group are separated by a space, and the groups by ||, for example:
+
+>=1.2.0 <2.0.0 || ^3.1
+
+Each clause is written with its Operator kept verbatim, so a caret or tilde
+range is not expanded, followed by the version without a prefix, unless the
+config uses the RequirePrefix Option. Partial versions keep their omitted
+version numbers, and wildcards are written as partial versions, so 1.2.x is
+written as 1.2. Hyphen ranges are written as their inclusive bounds, and a
+group which matches any version, such as an empty constraint, is written as *.
+*/
+func (c *Constraint) String() string {
+	groups := make([]string, len(c.groups))
+	for i, group := range c.groups {
+		if len(group) == 0 {
+			groups[i] = "*"
+			continue
+		}
+
+		clauses := make([]string, len(group))
+		for j, clause := range group {
+			clauses[j] = clause.clauseString()
+		}
+		groups[i] = strings.Join(clauses, " ")
+	}
+	return strings.Join(groups, " || ")
+}
+
+// clauseString returns the version as a constraint clause, with its Operator
+// and any omitted version numbers left out.
+func (v *Version) clauseString() string {
+	var s strings.Builder
+	s.WriteString(string(v.operator))
+	if v.epoch > 0 {
+		s.WriteString(fmt.Sprintf("%d:", v.epoch))
+	}
+
+	if v.conf().requirePrefix {
+		s.WriteString("v")
+	}
+
+	nums := []uint64{v.major, v.minor, v.patch}[:3-v.omitted]
+	for i, n := range nums {
+		if i > 0 {
+			s.WriteString(".")
+		}
+		s.WriteString(fmt.Sprintf("%d", n))
+	}
+	if v.omitted == 0 && v.build > 0 {
+		s.WriteString(fmt.Sprintf(".%d", v.build))
+	}
+
+	if v.preRelease != "" {
+		s.WriteString("-")
+		s.WriteString(v.preRelease)
+	}
+	if v.buildMetadata != "" {
+		s.WriteString("+")
+		s.WriteString(v.buildMetadata)
+	}
+	return s.String()
+}
+
+/*
+And returns a new Constraint which is satisfied only by versions which satisfy
+both the Constraint and the other Constraint. Neither Constraint is modified,
+and the result uses the config of the Constraint.
+*/
+func (c *Constraint) And(other *Constraint) *Constraint {
+	and := &Constraint{config: c.config}
+	for _, group := range c.groups {
+		for _, o := range other.groups {
+			clauses := make([]*Version, 0, len(group)+len(o))
+			clauses = append(clauses, group...)
+			and.groups = append(and.groups, append(clauses, o...))
+		}
+	}
+	return and
+}
+
+/*
+Or returns a new Constraint which is satisfied by versions which satisfy
+either the Constraint or the other Constraint, as if their groups were joined
+with ||. Neither Constraint is modified, and the result uses the config of the
+Constraint.
+*/
+func (c *Constraint) Or(other *Constraint) *Constraint {
+	or := &Constraint{config: c.config}
+	or.groups = append(or.groups, c.groups...)
+	or.groups = append(or.groups, other.groups...)
+	return or
+}
+
+/*
+Check returns true if the version satisfies every clause of any one of the
+Constraint's || separated groups.
+
+As with npm, a pre release version only satisfies a group if one of its
+clauses has a pre release on the same major, minor, and patch version, so
+v1.2.3-beta does not satisfy >=1.0.0, but satisfies >=1.2.3-alpha. Use the
+IncludePrerelease Option to match pre releases like any other version.
+
+A nil version never satisfies the Constraint.
+*/
+func (c *Constraint) Check(v *Version) bool {
+	if v == nil {
+		return false
+	}
+	if !c.conf().includePreReleases {
+		return c.checkVisible(v)
+	}
+
+	for _, group := range c.groups {
+		if checkClauses(group, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// conf returns the Constraint config, or the default config for a Constraint
+// created without one.
+func (c *Constraint) conf() *config {
+	if c.config == nil {
+		return currentConfig()
+	}
+	return c.config
+}
+
+/*
+CheckWithReason is Check, but on failure also returns a reason naming the
+clause the version violated in each || separated group, such as:
+
+v1.5.0 does not satisfy <1.4.0
+
+The reasons of several groups are separated by a semicolon, and a pre release
+hidden by the pre release visibility rule is reported as such. The reason is
+empty when the version satisfies the Constraint.
+*/
+func (c *Constraint) CheckWithReason(v *Version) (bool, string) {
+	if c.Check(v) {
+		return true, ""
+	}
+	if v == nil {
+		return false, "a nil version does not satisfy any constraint"
+	}
+	if len(c.groups) == 0 {
+		return false, "constraint has no groups"
+	}
+
+	reasons := make([]string, len(c.groups))
+	for i, group := range c.groups {
+		reasons[i] = groupReason(group, v)
+	}
+	return false, strings.Join(reasons, "; ")
+}
+
+// groupReason returns why the version does not satisfy the group of clauses,
+// which is either a failed clause, or the pre release visibility rule.
+func groupReason(clauses []*Version, v *Version) string {
+	for _, clause := range clauses {
+		if !clause.opCompare(v) {
+			return fmt.Sprintf("%s does not satisfy %s", v.String(), clause.clauseString())
+		}
+	}
+
+	group := &Constraint{groups: [][]*Version{clauses}}
+	return fmt.Sprintf("%s is a pre release not included by %s", v.String(), group.String())
+}
+
+/*
+checkVisible is Check with the npm pre release visibility rule, where a pre
+release version only satisfies a group of clauses if one of the clauses has a
+pre release on the same major, minor, and patch version.
+*/
+func (c *Constraint) checkVisible(v *Version) bool {
+	for _, group := range c.groups {
+		if checkClauses(group, v) && preReleaseVisible(group, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// preReleaseVisible returns true if the version is not a pre release, or one
+// of the clauses is a pre release of the same major, minor, and patch version.
+func preReleaseVisible(clauses []*Version, v *Version) bool {
+	if v.preRelease == "" {
+		return true
+	}
+	for _, clause := range clauses {
+		if clause.preRelease != "" && clause.epoch == v.epoch && clause.major == v.major &&
+			clause.minor == v.minor && clause.patch == v.patch {
+			return true
+		}
+	}
+	return false
+}
+
+// checkClauses returns true if the version satisfies every clause.
+func checkClauses(clauses []*Version, v *Version) bool {
+	for _, clause := range clauses {
+		if !clause.opCompare(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// AllSatisfied returns true if the version passes Check for every constraint,
+// or if there are no constraints.
+func AllSatisfied(v *Version, constraints []*Constraint) bool {
+	for _, c := range constraints {
+		if !c.Check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Satisfies parses the constraint string and returns true if the version passes
+it. An error is returned if the constraint is malformed, so mistakes are not
+silently treated as a failed check.
+*/
+func (v *Version) Satisfies(constraint string, conf ...*config) (bool, error) {
+	c, err := ParseConstraint(constraint, conf...)
+	if err != nil {
+		return false, err
+	}
+	return c.Check(v), nil
+}
+
+/*
+Filter returns the versions which satisfy the constraint string, in their
+original order. An error is returned if the constraint is malformed.
+*/
+func Filter(versions []*Version, constraint string, conf ...*config) ([]*Version, error) {
+	c, err := ParseConstraint(constraint, conf...)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*Version
+	for _, v := range versions {
+		if c.Check(v) {
+			matches = append(matches, v)
+		}
+	}
+	return matches, nil
+}
+
+/*
+LatestMatching returns the version with the highest precedence which
+satisfies the constraint string, or nil if no version matches. An error is
+returned if the constraint is malformed.
+
+As with Constraint.Check, pre release versions are excluded unless the
+constraint includes a pre release of the same major, minor, and patch version,
+so >=1.2.0-alpha can match v1.2.0-beta, but not v1.3.0-beta.
+*/
+func LatestMatching(versions []*Version, constraint string, conf ...*config) (*Version, error) {
+	c, err := ParseConstraint(constraint, conf...)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *Version
+	for _, v := range versions {
+		if c.Check(v) && (latest == nil || v.Compare(latest) > 0) {
+			latest = v
+		}
+	}
+	return latest, nil
+}
+
+/*
+HighestSatisfying returns the version with the highest precedence which
+satisfies the constraint string with Constraint.Check, or nil if no version
+matches. An error is returned if the constraint is malformed. If several
+versions share the highest precedence the first is returned.
+*/
+func HighestSatisfying(versions []*Version, constraint string, conf ...*config) (*Version, error) {
+	return satisfyingExtreme(versions, constraint, 1, conf)
+}
+
+/*
+LowestSatisfying returns the version with the lowest precedence which
+satisfies the constraint string with Constraint.Check, or nil if no version
+matches. An error is returned if the constraint is malformed. If several
+versions share the lowest precedence the first is returned.
+*/
+func LowestSatisfying(versions []*Version, constraint string, conf ...*config) (*Version, error) {
+	return satisfyingExtreme(versions, constraint, -1, conf)
+}
+
+// satisfyingExtreme returns the first satisfying version which compares in
+// the dir direction, 1 or -1, against every other satisfying version.
+func satisfyingExtreme(versions []*Version, constraint string, dir int, conf []*config) (*Version, error) {
+	c, err := ParseConstraint(constraint, conf...)
+	if err != nil {
+		return nil, err
+	}
+
+	var extreme *Version
+	for _, v := range versions {
+		if c.Check(v) && (extreme == nil || v.Compare(extreme) == dir) {
+			extreme = v
+		}
+	}
+	return extreme, nil
+}
@@ -0,0 +1,687 @@
+package semver
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	. "github.com/franela/goblin"
+)
+
+// mustConstraint parses a constraint for tests, and panics on error.
+func mustConstraint(s string) *Constraint {
+	c, err := ParseConstraint(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func TestConstraint(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Constraint parsing and checks", func() {
+		g.It("Should check an inclusive lower and exclusive upper bound", func() {
+			c, err := ParseConstraint(">=1.2.0 <2.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.2.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.9.9").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.1.9").Get())).IsFalse()
+			g.Assert(c.Check(String("v2.0.0").Get())).IsFalse()
+		})
+
+		g.It("Should check a single clause", func() {
+			c, err := ParseConstraint("^1.2.0")
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.3.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v2.0.0").Get())).IsFalse()
+		})
+
+		g.It("Should exclude a version with a not equal clause", func() {
+			c, err := ParseConstraint(">=1.2.0 <2.0.0 != 1.4.0")
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.3.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.4.0").Get())).IsFalse()
+		})
+
+		g.It("Should check an explicit equal clause", func() {
+			c, err := ParseConstraint("=1.2.3 || == 1.4.0")
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.2.3").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.4.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.3.0").Get())).IsFalse()
+		})
+
+		g.It("Should check a caret with a wildcard", func() {
+			for _, r := range []string{"^1.x", "^1.X.x", "^1.*"} {
+				c, err := ParseConstraint(r)
+				g.Assert(err).IsNil()
+				g.Assert(c.Check(String("v1.0.0").Get())).IsTrue(r)
+				g.Assert(c.Check(String("v1.9.9").Get())).IsTrue(r)
+				g.Assert(c.Check(String("v0.9.9").Get())).IsFalse(r)
+				g.Assert(c.Check(String("v2.0.0").Get())).IsFalse(r)
+				g.Assert(c.Check(String("v2.0.0-rc.1").Get())).IsFalse(r)
+			}
+
+			c, err := ParseConstraint("^0.0.x")
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v0.0.5").Get())).IsTrue()
+			g.Assert(c.Check(String("v0.1.0").Get())).IsFalse()
+		})
+
+		g.It("Should check a tilde with a wildcard", func() {
+			for _, r := range []string{"~1.2.x", "~1.2.X", "~1.2.*"} {
+				c, err := ParseConstraint(r)
+				g.Assert(err).IsNil()
+				g.Assert(c.Check(String("v1.2.0").Get())).IsTrue(r)
+				g.Assert(c.Check(String("v1.2.9").Get())).IsTrue(r)
+				g.Assert(c.Check(String("v1.1.9").Get())).IsFalse(r)
+				g.Assert(c.Check(String("v1.3.0").Get())).IsFalse(r)
+			}
+
+			c, err := ParseConstraint("~1.x")
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.9.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v2.0.0").Get())).IsFalse()
+		})
+
+		g.It("Should hide pre releases without a pre release on the same version", func() {
+			c := mustConstraint(">=1.0.0")
+			g.Assert(c.Check(String("v1.2.3-beta").Get())).IsFalse()
+			g.Assert(c.Check(String("v1.2.3").Get())).IsTrue()
+
+			c = mustConstraint(">=1.2.3-alpha")
+			g.Assert(c.Check(String("v1.2.3-beta").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.2.4-beta").Get())).IsFalse()
+
+			c = mustConstraint("<1.0.0 || >=1.2.3-alpha <1.3.0")
+			g.Assert(c.Check(String("v1.2.3-beta").Get())).IsTrue()
+			g.Assert(c.Check(String("v0.9.0-beta").Get())).IsFalse()
+		})
+
+		g.It("Should show pre releases with the IncludePrerelease option", func() {
+			conf := DefaultConfig(IncludePrerelease())
+			c, err := ParseConstraint(">=1.0.0", conf)
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.2.3-beta").Get())).IsTrue()
+			g.Assert(c.Check(String("v0.9.0-beta").Get())).IsFalse()
+
+			ok, err := String("v1.2.4-beta").Get().Satisfies("^1.2.3", conf)
+			g.Assert(err).IsNil()
+			g.Assert(ok).IsTrue()
+		})
+
+		g.It("Should check a hyphen range", func() {
+			c, err := ParseConstraint("1.0.0 - 2.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.0.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v2.0.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v0.9.9").Get())).IsFalse()
+			g.Assert(c.Check(String("v2.0.1").Get())).IsFalse()
+		})
+
+		g.It("Should check a hyphen range with a partial upper bound", func() {
+			c, err := ParseConstraint("1.2.3 - 2.3")
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.2.3").Get())).IsTrue()
+			g.Assert(c.Check(String("v2.3.9").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.2.2").Get())).IsFalse()
+			g.Assert(c.Check(String("v2.4.0").Get())).IsFalse()
+		})
+
+		g.It("Should check || separated groups", func() {
+			c, err := ParseConstraint(">=1.0.0 <2.0.0 || >=3.0.0 <4.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.5.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v3.5.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v2.5.0").Get())).IsFalse()
+			g.Assert(c.Check(String("v4.0.0").Get())).IsFalse()
+		})
+
+		g.It("Should match any version with a match all token", func() {
+			for _, r := range []string{"*", "x", "X", "latest", "", "  "} {
+				c, err := ParseConstraint(r)
+				g.Assert(err).IsNil()
+				g.Assert(c.Check(String("v0.0.0").Get())).IsTrue(r)
+				g.Assert(c.Check(String("v1.2.3").Get())).IsTrue(r)
+				g.Assert(c.Check(String("v99.0.0+build").Get())).IsTrue(r)
+				g.Assert(c.Check(String("v1.2.3-rc.1").Get())).IsFalse(r)
+			}
+
+			c, err := ParseConstraint("latest", DefaultConfig(IncludePrerelease()))
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.2.3-rc.1").Get())).IsTrue()
+		})
+
+		g.It("Should return an error for an empty || group", func() {
+			_, err := ParseConstraint(">=1.0.0 ||")
+			g.Assert(err.Error()).Equal(`invalid constraint ">=1.0.0 ||": no versions`)
+		})
+
+		g.It("Should check a major wildcard range", func() {
+			for _, r := range []string{"1.x", "1.X", "1.*", "1.x.x", "1"} {
+				c, err := ParseConstraint(r)
+				g.Assert(err).IsNil()
+				g.Assert(c.Check(String("v1.0.0").Get())).IsTrue()
+				g.Assert(c.Check(String("v1.9.9").Get())).IsTrue()
+				g.Assert(c.Check(String("v0.9.9").Get())).IsFalse()
+				g.Assert(c.Check(String("v2.0.0").Get())).IsFalse()
+			}
+		})
+
+		g.It("Should check a minor wildcard range", func() {
+			for _, r := range []string{"1.2.x", "1.2.X", "1.2.*", "1.2"} {
+				c, err := ParseConstraint(r)
+				g.Assert(err).IsNil()
+				g.Assert(c.Check(String("v1.2.0").Get())).IsTrue()
+				g.Assert(c.Check(String("v1.2.9").Get())).IsTrue()
+				g.Assert(c.Check(String("v1.1.9").Get())).IsFalse()
+				g.Assert(c.Check(String("v1.3.0").Get())).IsFalse()
+			}
+		})
+
+		g.It("Should match any version with a bare wildcard", func() {
+			for _, r := range []string{"*", "x", "X", "x.x.x", "*.*.*", "X.x", ">=1.0.0 || x.x.x"} {
+				c, err := ParseConstraint(r)
+				g.Assert(err).IsNil()
+				g.Assert(c.Check(String("v0.0.0").Get())).IsTrue()
+				g.Assert(c.Check(String("v9.9.9").Get())).IsTrue()
+			}
+		})
+
+		g.It("Should return an error for a wildcard before a version number", func() {
+			_, err := ParseConstraint("1.x.3")
+			g.Assert(err.Error()).Equal(`invalid constraint "1.x.3": invalid semantic version: "1.x.3": wildcard before version number "3"`)
+		})
+
+		g.It("Should allow whitespace between an operator and version", func() {
+			c, err := ParseConstraint(">= 1.2.0 < 2.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.2.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v2.0.0").Get())).IsFalse()
+		})
+
+		g.It("Should support custom Operator syntax", func() {
+			conf := Config(Operators{
+				GTE: Operator("+="),
+				LT:  Operator("-"),
+			}, `\+=|-`)
+			c, err := ParseConstraint("+=1.2.0 -2.0.0", conf)
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.5.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v2.0.0").Get())).IsFalse()
+		})
+
+		g.It("Should return an error for an operator with a wildcard", func() {
+			_, err := ParseConstraint(">=1.0.0 || >=1.x")
+			g.Assert(err.Error()).Equal(`invalid constraint ">=1.0.0 || >=1.x": invalid clause ">=1.x": operator ">=" cannot be used with a wildcard version number`)
+
+			_, err = ParseConstraint("^*")
+			g.Assert(err.Error()).Equal(`invalid constraint "^*": invalid clause "^*": operator "^" cannot be used with a bare wildcard`)
+
+			_, err = ParseConstraint(">=x.x.x")
+			g.Assert(err.Error()).Equal(`invalid constraint ">=x.x.x": invalid clause ">=x.x.x": operator ">=" cannot be used with a bare wildcard`)
+
+			_, err = ParseConstraint("< 1.2.*")
+			g.Assert(err.Error()).Equal(`invalid constraint "< 1.2.*": invalid clause "<1.2.*": operator "<" cannot be used with a wildcard version number`)
+
+			for _, r := range []string{"1.x", "^1.x", "~1.2.x", ">=1", "<1.2", "=1.x", "1.x - 2.x", ">=1.0.0-x.1"} {
+				_, err := ParseConstraint(r)
+				g.Assert(err).IsNil(r)
+			}
+		})
+
+		g.It("Should return an error for a malformed clause", func() {
+			c, err := ParseConstraint(">=1.2.0 <two")
+			g.Assert(c == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`invalid constraint ">=1.2.0 <two": invalid semantic version: "<two"`)
+		})
+
+		g.It("Should return an error for an unknown operator", func() {
+			c, err := ParseConstraint(">>1.2.3")
+			g.Assert(c == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`invalid constraint ">>1.2.3": invalid clause ">>1.2.3": unknown operator ">>"`)
+
+			_, err = ParseConstraint(">=1.0.0 |1.2.3")
+			g.Assert(err.Error()).Equal(`invalid constraint ">=1.0.0 |1.2.3": invalid clause "|1.2.3": unknown operator "|"`)
+
+			_, err = ParseConstraint("<>1.2.3")
+			g.Assert(err == nil).IsFalse()
+		})
+
+		g.It("Should not join an operator to another operator", func() {
+			c, err := ParseConstraint("> =1.0.0")
+			g.Assert(c == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`invalid constraint "> =1.0.0": invalid semantic version: ">"`)
+
+			_, err = ParseConstraint("< =1.0.0")
+			g.Assert(err == nil).IsFalse()
+		})
+
+		g.It("Should return an error for a hyphen range with an operator", func() {
+			c, err := ParseConstraint("< 1.0.0 - 2.0.0")
+			g.Assert(c == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`invalid constraint "< 1.0.0 - 2.0.0": invalid clause "< 1.0.0 - 2.0.0": hyphen ranges cannot have an operator`)
+
+			_, err = ParseConstraint("> 1.0.0 - 2.0.0")
+			g.Assert(err.Error()).Equal(`invalid constraint "> 1.0.0 - 2.0.0": invalid clause "> 1.0.0 - 2.0.0": hyphen ranges cannot have an operator`)
+
+			_, err = ParseConstraint("<1.0.0 - 2.0.0")
+			g.Assert(err == nil).IsFalse()
+		})
+
+		g.It("Should return an error for a hyphen range without GTE and LTE operators", func() {
+			conf := Config(Operators{GTE: Operator("+="), LT: Operator("<")}, `\+=|<`)
+			c, err := ParseConstraint("1.0.0 - 2.0.0", conf)
+			g.Assert(c == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`invalid constraint "1.0.0 - 2.0.0": invalid clause "1.0.0 - 2.0.0": hyphen ranges need the GTE and LTE operators`)
+		})
+	})
+}
+
+func TestConstraintNil(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Constraint nil handling", func() {
+		g.It("Should not match a nil version", func() {
+			g.Assert(mustConstraint("<2.0.0").Check(nil)).IsFalse()
+			g.Assert(mustConstraint("*").Check(nil)).IsFalse()
+			c, err := ParseConstraint("<2.0.0", DefaultConfig(IncludePrerelease()))
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(nil)).IsFalse()
+
+			ok, reason := mustConstraint("<2.0.0").CheckWithReason(nil)
+			g.Assert(ok).IsFalse()
+			g.Assert(reason).Equal("a nil version does not satisfy any constraint")
+		})
+
+		g.It("Should skip nil versions in a slice", func() {
+			versions := []*Version{nil, String("v1.2.0").Get(), nil, String("v1.5.0").Get()}
+
+			matches, err := Filter(versions, "<2.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(versionStrings(matches)).Equal([]string{"v1.2.0", "v1.5.0"})
+
+			out, err := Versions(versions).Filter("*")
+			g.Assert(err).IsNil()
+			g.Assert(len(out)).Equal(2)
+
+			latest, err := LatestMatching(versions, "<2.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(latest.String()).Equal("v1.5.0")
+
+			high, err := HighestSatisfying(versions, "<2.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(high.String()).Equal("v1.5.0")
+
+			low, err := LowestSatisfying(versions, "<2.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(low.String()).Equal("v1.2.0")
+		})
+
+		g.It("Should use the default config for a zero Constraint", func() {
+			c := &Constraint{}
+			g.Assert(c.Check(String("v1.0.0").Get())).IsFalse()
+			g.Assert(c.Check(String("v1.0.0-rc.1").Get())).IsFalse()
+
+			c = &Constraint{groups: [][]*Version{{}}}
+			g.Assert(c.Check(String("v1.0.0").Get())).IsTrue()
+		})
+
+		g.It("Should return a reason for a zero Constraint", func() {
+			ok, reason := (&Constraint{}).CheckWithReason(String("v1.0.0").Get())
+			g.Assert(ok).IsFalse()
+			g.Assert(reason).Equal("constraint has no groups")
+		})
+	})
+}
+
+func TestConstraintCombine(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Constraint combination", func() {
+		gte, _ := ParseConstraint(">=1.0.0")
+		lt, _ := ParseConstraint("<2.0.0")
+
+		g.It("Should require both constraints with And", func() {
+			c := gte.And(lt)
+			g.Assert(c.Check(String("v1.0.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.9.9").Get())).IsTrue()
+			g.Assert(c.Check(String("v0.9.0").Get())).IsFalse()
+			g.Assert(c.Check(String("v2.0.0").Get())).IsFalse()
+		})
+
+		g.It("Should require either constraint with Or", func() {
+			c := lt.Or(mustConstraint(">=3.0.0"))
+			g.Assert(c.Check(String("v1.0.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v3.1.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v2.5.0").Get())).IsFalse()
+		})
+
+		g.It("Should combine || groups", func() {
+			c := mustConstraint("^1.0.0 || ^3.0.0").And(mustConstraint("!=1.5.0 !=3.5.0"))
+			g.Assert(c.Check(String("v1.4.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v3.4.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.5.0").Get())).IsFalse()
+			g.Assert(c.Check(String("v3.5.0").Get())).IsFalse()
+			g.Assert(c.Check(String("v2.0.0").Get())).IsFalse()
+
+			c = gte.And(lt).Or(mustConstraint("^3.0.0")).And(mustConstraint("!=3.2.0"))
+			g.Assert(c.Check(String("v1.2.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v3.1.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v3.2.0").Get())).IsFalse()
+		})
+
+		g.It("Should not modify the combined constraints", func() {
+			gte.And(lt)
+			gte.Or(lt)
+			g.Assert(gte.Check(String("v2.0.0").Get())).IsTrue()
+			g.Assert(lt.Check(String("v0.1.0").Get())).IsTrue()
+		})
+	})
+}
+
+func TestCheckWithReason(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Constraint check reasons", func() {
+		c := mustConstraint(">=1.2.0 <1.4.0")
+
+		g.It("Should return no reason for a passing version", func() {
+			ok, reason := c.CheckWithReason(String("v1.3.0").Get())
+			g.Assert(ok).IsTrue()
+			g.Assert(reason).Equal("")
+		})
+
+		g.It("Should name a failing lower bound", func() {
+			ok, reason := c.CheckWithReason(String("v1.1.9").Get())
+			g.Assert(ok).IsFalse()
+			g.Assert(reason).Equal("v1.1.9 does not satisfy >=1.2.0")
+		})
+
+		g.It("Should name a failing upper bound", func() {
+			ok, reason := c.CheckWithReason(String("v1.5.0").Get())
+			g.Assert(ok).IsFalse()
+			g.Assert(reason).Equal("v1.5.0 does not satisfy <1.4.0")
+		})
+
+		g.It("Should give a reason for each group", func() {
+			ok, reason := mustConstraint("^1.2 || ~3.1.0").CheckWithReason(String("v2.0.0").Get())
+			g.Assert(ok).IsFalse()
+			g.Assert(reason).Equal("v2.0.0 does not satisfy ^1.2; v2.0.0 does not satisfy ~3.1.0")
+		})
+
+		g.It("Should explain a hidden pre release", func() {
+			ok, reason := c.CheckWithReason(String("v1.3.0-beta").Get())
+			g.Assert(ok).IsFalse()
+			g.Assert(reason).Equal("v1.3.0-beta is a pre release not included by >=1.2.0 <1.4.0")
+		})
+	})
+}
+
+func TestConstraintString(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Constraint string", func() {
+		versions := getVersions(
+			"v0.9.0", "v1.0.0", "v1.2.0", "v1.2.3", "v1.9.9", "v2.0.0", "v2.3.4", "v3.0.0-rc.1",
+			"v3.1.0", "v3.1.5", "v3.2.0", "v4.0.0",
+		)
+
+		// roundTrip checks the canonical string parses to an equivalent constraint.
+		roundTrip := func(c *Constraint) {
+			back, err := ParseConstraint(c.String())
+			g.Assert(err).IsNil(c.String())
+			g.Assert(back.String()).Equal(c.String())
+			for _, v := range versions {
+				g.Assert(back.Check(v)).Equal(c.Check(v), v.String())
+			}
+		}
+
+		g.It("Should round trip a two clause constraint", func() {
+			c := mustConstraint(">=v1.2.0 <2.0.0")
+			g.Assert(c.String()).Equal(">=1.2.0 <2.0.0")
+			roundTrip(c)
+		})
+
+		g.It("Should round trip an OR constraint", func() {
+			c := mustConstraint(">=1.0.0 <1.2.3 || ^3.1 || 4.x")
+			g.Assert(c.String()).Equal(">=1.0.0 <1.2.3 || ^3.1 || 4")
+			roundTrip(c)
+		})
+
+		g.It("Should write hyphen ranges as inclusive bounds", func() {
+			c := mustConstraint("1.2.3 - 2.3")
+			g.Assert(c.String()).Equal(">=1.2.3 <=2.3")
+			roundTrip(c)
+		})
+
+		g.It("Should keep pre releases, metadata, and separated operators", func() {
+			c := mustConstraint("~ 1.2.3-rc.1+build != 1.2.5")
+			g.Assert(c.String()).Equal("~1.2.3-rc.1+build !=1.2.5")
+			roundTrip(c)
+		})
+
+		g.It("Should write a constraint matching any version as *", func() {
+			g.Assert(mustConstraint("").String()).Equal("*")
+			g.Assert(mustConstraint("latest || 1.x").String()).Equal("* || 1")
+			roundTrip(mustConstraint("* || 1.x"))
+		})
+
+		g.It("Should round trip with a config", func() {
+			conf := RubyConfig()
+			c, err := ParseConstraint("~> 1.2 != 1.3.0", conf)
+			g.Assert(err).IsNil()
+			g.Assert(c.String()).Equal("~>1.2 !=1.3.0")
+
+			back, err := ParseConstraint(c.String(), conf)
+			g.Assert(err).IsNil()
+			g.Assert(back.Check(String("v1.3.0").Get())).IsFalse()
+			g.Assert(back.Check(String("v1.4.0").Get())).IsTrue()
+		})
+	})
+}
+
+func TestGetConstraint(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("String constraint parsing", func() {
+		g.It("Should parse a range from a JSON field", func() {
+			var dep struct {
+				Name  string `json:"name"`
+				Range String `json:"range"`
+			}
+			err := json.Unmarshal([]byte(`{"name": "semver", "range": ">=1.0.0 <2.0.0"}`), &dep)
+			g.Assert(err).IsNil()
+			g.Assert(dep.Range).Equal(String(">=1.0.0 <2.0.0"))
+
+			c, err := dep.Range.GetConstraint()
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.5.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v2.0.0").Get())).IsFalse()
+			g.Assert(c.Check(String("v0.9.0").Get())).IsFalse()
+		})
+
+		g.It("Should use the config", func() {
+			c, err := String("~> 1.2").GetConstraint(RubyConfig())
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.9.0").Get())).IsTrue()
+		})
+
+		g.It("Should return an error for a malformed range", func() {
+			c, err := String(">=1.0.0 <two").GetConstraint()
+			g.Assert(c == nil).IsTrue()
+			g.Assert(err == nil).IsFalse()
+		})
+	})
+}
+
+func TestConstraintList(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Constraint lists", func() {
+		g.It("Should parse a list of constraints", func() {
+			var list []string
+			g.Assert(json.Unmarshal([]byte(`[">=1.2.0", "<2.0.0", "!=1.4.1 || >=1.5.0-rc.1"]`), &list)).IsNil()
+
+			constraints, err := ParseConstraints(list)
+			g.Assert(err).IsNil()
+			g.Assert(len(constraints)).Equal(3)
+			g.Assert(constraints[2].String()).Equal("!=1.4.1 || >=1.5.0-rc.1")
+		})
+
+		g.It("Should check a version against every constraint", func() {
+			constraints, err := ParseConstraints([]string{">=1.2.0", "<2.0.0", "!=1.4.1", "~1.4"})
+			g.Assert(err).IsNil()
+
+			g.Assert(AllSatisfied(String("v1.4.0").Get(), constraints)).IsTrue()
+			// satisfies all but the != constraint
+			g.Assert(AllSatisfied(String("v1.4.1").Get(), constraints)).IsFalse()
+			g.Assert(AllSatisfied(String("v1.5.0").Get(), constraints)).IsFalse()
+			g.Assert(AllSatisfied(String("v1.5.0").Get(), nil)).IsTrue()
+		})
+
+		g.It("Should return an error for a malformed constraint", func() {
+			constraints, err := ParseConstraints([]string{">=1.2.0", ">=1.x"})
+			g.Assert(constraints == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`invalid constraint ">=1.x": invalid clause ">=1.x": operator ">=" cannot be used with a wildcard version number`)
+		})
+
+		g.It("Should parse with a config", func() {
+			constraints, err := ParseConstraints([]string{"~> 1.2", "!= 1.3.0"}, RubyConfig())
+			g.Assert(err).IsNil()
+			g.Assert(AllSatisfied(String("v1.2.5").Get(), constraints)).IsTrue()
+			g.Assert(AllSatisfied(String("v1.3.0").Get(), constraints)).IsFalse()
+		})
+	})
+}
+
+func TestSatisfies(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version constraint satisfaction", func() {
+		g.It("Should satisfy a matching constraint", func() {
+			ok, err := String("v1.5.0").Get().Satisfies(">=1.2.0 <2.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(ok).IsTrue()
+		})
+
+		g.It("Should not satisfy a failing constraint", func() {
+			ok, err := String("v2.5.0").Get().Satisfies(">=1.2.0 <2.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(ok).IsFalse()
+		})
+
+		g.It("Should return an error for a malformed constraint", func() {
+			ok, err := String("v1.5.0").Get().Satisfies(">=1.2.0 <=>2")
+			g.Assert(ok).IsFalse()
+			g.Assert(err == nil).IsFalse()
+		})
+	})
+}
+
+func TestFilter(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Filtering versions by constraint", func() {
+		g.It("Should return matching versions in their original order", func() {
+			versions := getVersions("v1.5.0", "v0.9.0", "v2.0.0", "v1.0.0", "v1.9.9", "v2.1.0")
+			matches, err := Filter(versions, ">=1.0.0 <2.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(versionStrings(matches)).Equal([]string{"v1.5.0", "v1.0.0", "v1.9.9"})
+		})
+
+		g.It("Should return an error for a malformed constraint", func() {
+			matches, err := Filter(getVersions("v1.0.0"), ">=one")
+			g.Assert(matches == nil).IsTrue()
+			g.Assert(err == nil).IsFalse()
+		})
+	})
+}
+
+func TestLatestMatching(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Latest version matching a constraint", func() {
+		versions := getVersions(
+			"v1.2.0", "v1.9.0", "v1.4.0", "v2.0.0-rc.1", "v2.0.0", "v1.10.0-beta",
+		)
+
+		g.It("Should return the highest matching stable version", func() {
+			v, err := LatestMatching(versions, "^1.2.0")
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.9.0")
+		})
+
+		g.It("Should return nil when no version matches", func() {
+			v, err := LatestMatching(versions, ">=3.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(v == nil).IsTrue()
+		})
+
+		g.It("Should exclude pre releases without a pre release in the constraint", func() {
+			pre := getVersions("v1.2.0-alpha", "v1.2.0-beta", "v1.3.0-beta")
+			v, err := LatestMatching(pre, ">=1.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(v == nil).IsTrue()
+		})
+
+		g.It("Should include pre releases of the constraint pre release version", func() {
+			pre := getVersions("v1.2.0-alpha", "v1.2.0-beta", "v1.3.0-beta")
+			v, err := LatestMatching(pre, ">=1.2.0-alpha")
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.2.0-beta")
+		})
+
+		g.It("Should return an error for a malformed constraint", func() {
+			_, err := LatestMatching(versions, "^one")
+			g.Assert(err == nil).IsFalse()
+		})
+	})
+}
+
+func TestSatisfyingExtremes(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Highest and lowest version satisfying a constraint", func() {
+		versions := getVersions(
+			"v1.4.0", "v1.2.0", "v2.0.0", "v1.9.0", "v1.1.0", "v1.9.0+build.2",
+		)
+
+		g.It("Should return the newest allowed version", func() {
+			v, err := HighestSatisfying(versions, ">=1.2.0 <2.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.9.0")
+		})
+
+		g.It("Should return the oldest allowed version", func() {
+			v, err := LowestSatisfying(versions, ">=1.2.0 <2.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.2.0")
+		})
+
+		g.It("Should return nil when no version matches", func() {
+			v, err := HighestSatisfying(versions, "^3.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(v == nil).IsTrue()
+			v, err = LowestSatisfying(versions, "^3.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(v == nil).IsTrue()
+			v, err = HighestSatisfying(nil, "^1.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(v == nil).IsTrue()
+		})
+
+		g.It("Should return an error for a malformed constraint", func() {
+			_, err := HighestSatisfying(versions, "^one")
+			g.Assert(err == nil).IsFalse()
+			_, err = LowestSatisfying(versions, "^one")
+			g.Assert(err == nil).IsFalse()
+		})
+	})
+}
+
+func ExampleParseConstraint() {
+	c, err := ParseConstraint(">=v1.2.0 <v2.0.0")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(c.Check(String("v1.4.2").Get()))
+	// Output: true
+}
+
+func ExampleVersion_Satisfies() {
+	ok, _ := String("v1.4.2").Get().Satisfies("^1.2.0")
+	fmt.Println(ok)
+	// Output: true
+}
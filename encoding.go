@@ -0,0 +1,127 @@
+package semver
+
+import (
+	"bufio"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+)
+
+/*
+Scan implements the sql.Scanner interface, parsing a string or []byte
+database value into the version with String.Parse.
+
+Scanning a NULL value returns an error, as there is no valid version to
+represent it. To support NULL, scan into a sql.NullString and parse its String
+when Valid is true.
+*/
+func (v *Version) Scan(src interface{}) error {
+	var s String
+	switch t := src.(type) {
+	case string:
+		s = String(t)
+	case []byte:
+		s = String(t)
+	case nil:
+		return fmt.Errorf("cannot scan NULL into a semantic version")
+	default:
+		return fmt.Errorf("cannot scan %T into a semantic version", src)
+	}
+
+	parsed, err := s.Parse(v.config)
+	if err != nil {
+		return err
+	}
+	*v = *parsed
+	return nil
+}
+
+// Value implements the driver.Valuer interface, returning the version as a
+// semver.String including any Operator with ToString, so a parsed version
+// keeps the "v" prefix of its input regardless of the WithPrefix Option.
+func (v *Version) Value() (driver.Value, error) {
+	return string(v.ToString()), nil
+}
+
+/*
+Set implements the flag.Value interface together with Version.String, parsing
+the flag value s into the version with String.Parse, so a version can be used
+as a command line flag:
+
+flag.Var(&v, "min-version", "minimum supported version")
+
+The version config is used if it has one, otherwise the default config. An
+error is returned for an invalid version.
+*/
+func (v *Version) Set(s string) error {
+	parsed, err := String(s).Parse(v.config)
+	if err != nil {
+		return err
+	}
+	*v = *parsed
+	return nil
+}
+
+/*
+MarshalYAML implements the yaml.Marshaler interface of gopkg.in/yaml.v3,
+writing the version as a semver.String including any Operator with ToString,
+so a parsed version keeps the "v" prefix of its input regardless of the
+WithPrefix Option.
+*/
+func (v *Version) MarshalYAML() (interface{}, error) {
+	return string(v.ToString()), nil
+}
+
+/*
+UnmarshalYAML implements the obsolete yaml.Unmarshaler interface supported by
+gopkg.in/yaml.v3 and gopkg.in/yaml.v2, parsing a YAML string into the version
+with String.Parse. An error is returned for an invalid version.
+*/
+func (v *Version) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	parsed, err := String(s).Parse(v.config)
+	if err != nil {
+		return err
+	}
+	*v = *parsed
+	return nil
+}
+
+/*
+ScanVersions reads a newline delimited list of versions from r, such as the
+output of git tag, and parses each line with String.Parse. Surrounding
+whitespace is trimmed, and blank lines and lines starting with # are skipped.
+
+The valid versions are returned in the order they were read, along with an
+error for each invalid line, which includes its line number. An error reading
+from r is returned as the last error.
+*/
+func ScanVersions(r io.Reader, conf ...*config) ([]*Version, []error) {
+	var versions []*Version
+	var errs []error
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		v, err := String(line).Parse(conf...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", n, err))
+			continue
+		}
+		versions = append(versions, v)
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return versions, errs
+}
@@ -0,0 +1,173 @@
+package semver
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/franela/goblin"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSQL(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("database/sql support", func() {
+		g.It("Should scan a []byte", func() {
+			var v Version
+			g.Assert(v.Scan([]byte("v1.2.3-rc.1+build"))).IsNil()
+			g.Assert(v.String()).Equal("v1.2.3-rc.1+build")
+		})
+
+		g.It("Should scan a string", func() {
+			var v Version
+			g.Assert(v.Scan(">=1.2.3")).IsNil()
+			g.Assert(v.Operator()).Equal(">=")
+			g.Assert(v.String()).Equal("v1.2.3")
+		})
+
+		g.It("Should return an error scanning NULL", func() {
+			var v Version
+			err := v.Scan(nil)
+			g.Assert(err.Error()).Equal("cannot scan NULL into a semantic version")
+		})
+
+		g.It("Should return an error scanning other types", func() {
+			var v Version
+			err := v.Scan(int64(1))
+			g.Assert(err.Error()).Equal("cannot scan int64 into a semantic version")
+		})
+
+		g.It("Should return an error scanning an invalid version", func() {
+			var v Version
+			err := v.Scan("nosemver")
+			g.Assert(err.Error()).Equal(`invalid semantic version: "nosemver"`)
+		})
+
+		g.It("Should return the string value", func() {
+			val, err := String(">=v1.2.3+build").Get().Value()
+			g.Assert(err).IsNil()
+			g.Assert(val).Equal(">=v1.2.3+build")
+		})
+	})
+}
+
+func TestFlag(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("flag.Value support", func() {
+		g.It("Should set and print a version", func() {
+			var v Version
+			g.Assert(v.Set("1.2.3-rc.1")).IsNil()
+			g.Assert(v.String()).Equal("v1.2.3-rc.1")
+			g.Assert(v.Set(v.String())).IsNil()
+			g.Assert(v.String()).Equal("v1.2.3-rc.1")
+		})
+
+		g.It("Should parse a command line flag", func() {
+			var v Version
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(&v, "min-version", "minimum supported version")
+
+			g.Assert(fs.Parse([]string{"-min-version", "v2.4.0"})).IsNil()
+			g.Assert(v.String()).Equal("v2.4.0")
+			g.Assert(fs.Lookup("min-version").Value.String()).Equal("v2.4.0")
+		})
+
+		g.It("Should return an error for an invalid version", func() {
+			var v Version
+			g.Assert(v.Set("nosemver").Error()).Equal(`invalid semantic version: "nosemver"`)
+
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(&v, "min-version", "minimum supported version")
+			g.Assert(fs.Parse([]string{"-min-version", "1.2"}) == nil).IsFalse()
+		})
+	})
+}
+
+func TestYAML(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("YAML support", func() {
+		type deployment struct {
+			Name    string   `yaml:"name"`
+			Version *Version `yaml:"version"`
+			Minimum *Version `yaml:"minimum"`
+		}
+
+		g.It("Should round trip a version in a YAML document", func() {
+			doc := "name: api\nversion: v1.2.3-rc.1+build.5\nminimum: '>=1.0.0'\n"
+			var d deployment
+			g.Assert(yaml.Unmarshal([]byte(doc), &d)).IsNil()
+			g.Assert(d.Version.String()).Equal("v1.2.3-rc.1+build.5")
+			g.Assert(d.Minimum.Operator()).Equal(">=")
+			g.Assert(d.Minimum.OpCompare(d.Version)).IsTrue()
+
+			out, err := yaml.Marshal(d)
+			g.Assert(err).IsNil()
+			g.Assert(string(out)).Equal(doc)
+
+			var back deployment
+			g.Assert(yaml.Unmarshal(out, &back)).IsNil()
+			g.Assert(back.Version.Compare(d.Version)).Equal(0)
+			g.Assert(back.Minimum.ToString()).Equal(d.Minimum.ToString())
+		})
+
+		g.It("Should return an error for an invalid version", func() {
+			var d deployment
+			err := yaml.Unmarshal([]byte("version: nosemver\n"), &d)
+			g.Assert(err.Error()).Equal(`invalid semantic version: "nosemver"`)
+
+			err = yaml.Unmarshal([]byte("version: [1, 2]\n"), &d)
+			g.Assert(err == nil).IsFalse()
+		})
+	})
+}
+
+func TestScanVersions(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Scanning a version list", func() {
+		g.It("Should collect valid versions and per line errors", func() {
+			list := "# release tags\nv1.0.0\n\n  v1.1.0-rc.1  \nnosemver\nv1.2.3\n#v9.9.9\nv1.02.0\n"
+			versions, errs := ScanVersions(strings.NewReader(list))
+
+			g.Assert(len(versions)).Equal(3)
+			g.Assert(versions[0].String()).Equal("v1.0.0")
+			g.Assert(versions[1].String()).Equal("v1.1.0-rc.1")
+			g.Assert(versions[2].String()).Equal("v1.2.3")
+
+			g.Assert(len(errs)).Equal(2)
+			g.Assert(errs[0].Error()).Equal(`line 5: invalid semantic version: "nosemver"`)
+			g.Assert(errs[1].Error()).Equal(`line 8: invalid semantic version: "v1.02.0": minor version "02" has a leading zero`)
+			g.Assert(errors.Is(errs[1], ErrLeadingZero)).IsTrue()
+		})
+
+		g.It("Should parse with a config", func() {
+			versions, errs := ScanVersions(strings.NewReader("1.2.3.4\r\n1.2.3\r\n"), DefaultConfig(WithBuildNumber()))
+			g.Assert(len(errs)).Equal(0)
+			g.Assert(versions[0].String()).Equal("v1.2.3.4")
+			g.Assert(versions[1].String()).Equal("v1.2.3.0")
+		})
+
+		g.It("Should return nothing for an empty reader", func() {
+			versions, errs := ScanVersions(strings.NewReader(""))
+			g.Assert(versions == nil).IsTrue()
+			g.Assert(errs == nil).IsTrue()
+		})
+
+		g.It("Should return a read error", func() {
+			versions, errs := ScanVersions(io.MultiReader(strings.NewReader("v1.0.0\n"), errReader{}))
+			g.Assert(len(versions)).Equal(1)
+			g.Assert(len(errs)).Equal(1)
+			g.Assert(errs[0].Error()).Equal("read failed")
+		})
+	})
+}
+
+// errReader is an io.Reader which always fails.
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("read failed")
+}
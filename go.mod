@@ -1,5 +1,7 @@
 module github.com/afloesch/semver
 
-go 1.18
+go 1.21
 
 require github.com/franela/goblin v0.0.0-20211003143422-0a4f594942bf
+
+require gopkg.in/yaml.v3 v3.0.1
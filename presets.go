@@ -0,0 +1,64 @@
+package semver
+
+/*
+NpmConfig returns a config following the npm semver conventions, with the
+default operators, x-ranges, and hyphen ranges. As with npm, a pre release
+version only satisfies an Operator or Constraint which includes a pre release
+on the same major, minor, and patch version, so v1.2.4-beta does not satisfy
+^1.2.3, but v1.2.3-beta satisfies >=1.2.3-alpha. Other configs only apply the
+rule to a Constraint, unless they use the HidePrerelease Option.
+*/
+func NpmConfig() *config {
+	return DefaultConfig(HidePrerelease())
+}
+
+/*
+RubyConfig returns a config for Rubygems and Bundler style constraints, such
+as ~> 1.2, with the following operators:
+
+~> - Pessimistic, allowing changes to only the last specified version number.
+
+>= - Greater than or equal to.
+
+> - Greater than.
+
+<= - Less than or equal to.
+
+< - Less than.
+
+= - Equal to.
+
+!= - Not equal to.
+*/
+func RubyConfig() *config {
+	return Config(Operators{
+		GT:          Operator(">"),
+		GTE:         Operator(">="),
+		LT:          Operator("<"),
+		LTE:         Operator("<="),
+		EQ:          Operator("="),
+		NEQ:         Operator("!="),
+		Pessimistic: Operator("~>"),
+	}, `~>|[>|<]+=?|!=|=`)
+}
+
+/*
+ComposerConfig returns a config following the PHP Composer conventions, with
+the default operators, * wildcards, and hyphen ranges.
+
+Composer's tilde Operator differs from npm, and allows changes to only the
+last specified version number, so ~1.2 allows >=1.2.0 <2.0.0, while
+~1.2.3 allows >=1.2.3 <1.3.0. The caret Operator matches npm.
+*/
+func ComposerConfig() *config {
+	return Config(Operators{
+		GT:          Operator(">"),
+		GTE:         Operator(">="),
+		LT:          Operator("<"),
+		LTE:         Operator("<="),
+		EQ:          Operator("="),
+		NEQ:         Operator("!="),
+		Caret:       Operator("^"),
+		Pessimistic: Operator("~"),
+	}, opRe)
+}
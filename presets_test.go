@@ -0,0 +1,168 @@
+package semver
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/franela/goblin"
+)
+
+func TestNpmConfig(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("npm preset config", func() {
+		conf := NpmConfig()
+
+		// satisfies mirrors the npm semver.satisfies function.
+		satisfies := func(version, constraint string) bool {
+			ok, err := String(version).Get(conf).Satisfies(constraint, conf)
+			g.Assert(err).IsNil()
+			return ok
+		}
+
+		g.It("Should follow the npm caret range examples", func() {
+			g.Assert(String("^1.2.3").Get(conf).OpCompare(String("1.9.9").Get())).IsTrue()
+			g.Assert(satisfies("1.9.9", "^1.2.3")).IsTrue()
+			g.Assert(satisfies("2.0.0", "^1.2.3")).IsFalse()
+			g.Assert(satisfies("0.2.9", "^0.2.3")).IsTrue()
+			g.Assert(satisfies("0.3.0", "^0.2.3")).IsFalse()
+			g.Assert(satisfies("0.0.3", "^0.0.3")).IsTrue()
+			g.Assert(satisfies("0.0.4", "^0.0.3")).IsFalse()
+		})
+
+		g.It("Should follow the npm tilde range examples", func() {
+			g.Assert(satisfies("1.2.9", "~1.2.3")).IsTrue()
+			g.Assert(satisfies("1.3.0", "~1.2.3")).IsFalse()
+			g.Assert(satisfies("1.2.0", "~1.2")).IsTrue()
+			g.Assert(satisfies("1.9.0", "~1")).IsTrue()
+			g.Assert(satisfies("2.0.0", "~1")).IsFalse()
+		})
+
+		g.It("Should follow the npm x-range and hyphen range examples", func() {
+			g.Assert(satisfies("1.9.9", "1.x")).IsTrue()
+			g.Assert(satisfies("1.2.9", "1.2.*")).IsTrue()
+			g.Assert(satisfies("3.0.0", "*")).IsTrue()
+			g.Assert(satisfies("2.3.4", "1.2.3 - 2.3.4")).IsTrue()
+			g.Assert(satisfies("2.3.5", "1.2.3 - 2.3.4")).IsFalse()
+			g.Assert(satisfies("2.3.9", "1.2.3 - 2.3")).IsTrue()
+		})
+
+		g.It("Should only match pre releases on the same version", func() {
+			g.Assert(satisfies("1.2.3-alpha.7", ">1.2.3-alpha.3")).IsTrue()
+			g.Assert(satisfies("3.4.5-alpha.9", ">1.2.3-alpha.3")).IsFalse()
+			g.Assert(satisfies("3.4.5", ">1.2.3-alpha.3")).IsTrue()
+			g.Assert(satisfies("1.2.4-beta", "^1.2.3")).IsFalse()
+			g.Assert(satisfies("1.2.3-beta", ">=1.2.3-alpha <1.3.0")).IsTrue()
+
+			v := String(">1.2.3-alpha.3").Get(conf)
+			g.Assert(v.OpCompare(String("1.2.3-alpha.7").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("3.4.5-alpha.9").Get())).IsFalse()
+		})
+
+		g.It("Should only hide pre releases from constraints without the npm config", func() {
+			ok, err := String("3.4.5-alpha.9").Get().Satisfies(">1.2.3-alpha.3")
+			g.Assert(err).IsNil()
+			g.Assert(ok).IsFalse()
+
+			v := String(">1.2.3-alpha.3").Get()
+			g.Assert(v.OpCompare(String("3.4.5-alpha.9").Get())).IsTrue()
+		})
+	})
+}
+
+func TestComposerConfig(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Composer preset config", func() {
+		conf := ComposerConfig()
+
+		g.It("Should allow minor changes with a tilde on a minor version", func() {
+			c, err := ParseConstraint("~1.2", conf)
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.2.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.9.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v2.0.0").Get())).IsFalse()
+
+			// npm allows only patch changes for the same range
+			c, err = ParseConstraint("~1.2", NpmConfig())
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.9.0").Get())).IsFalse()
+		})
+
+		g.It("Should allow patch changes with a tilde on a patch version", func() {
+			c, err := ParseConstraint("~1.2.3", conf)
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.2.9").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.3.0").Get())).IsFalse()
+		})
+
+		g.It("Should check a caret range", func() {
+			c, err := ParseConstraint("^1.2.3", conf)
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.9.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v2.0.0").Get())).IsFalse()
+		})
+
+		g.It("Should check a wildcard range", func() {
+			c, err := ParseConstraint("1.2.*", conf)
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.2.9").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.3.0").Get())).IsFalse()
+		})
+	})
+}
+
+func TestRubyConfig(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Rubygems preset config", func() {
+		g.It("Should check a pessimistic constraint on a minor version", func() {
+			c, err := ParseConstraint("~> 1.2", RubyConfig())
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.2.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.9.9").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.1.9").Get())).IsFalse()
+			g.Assert(c.Check(String("v2.0.0").Get())).IsFalse()
+		})
+
+		g.It("Should check a pessimistic constraint on a patch version", func() {
+			c, err := ParseConstraint("~> 1.2.3", RubyConfig())
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.2.3").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.2.9").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.2.2").Get())).IsFalse()
+			g.Assert(c.Check(String("v1.3.0").Get())).IsFalse()
+		})
+
+		g.It("Should check a pessimistic constraint on a major version", func() {
+			v := String("~>1").Get(RubyConfig())
+			g.Assert(v.OpCompare(String("v1.9.9").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v2.0.0").Get())).IsFalse()
+		})
+
+		g.It("Should check a not equal constraint", func() {
+			c, err := ParseConstraint("~> 1.2 != 1.3.0", RubyConfig())
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.2.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.3.0").Get())).IsFalse()
+			g.Assert(c.Check(String("v1.4.0").Get())).IsTrue()
+		})
+
+		g.It("Should combine with other operators", func() {
+			c, err := ParseConstraint("~> 1.2 >= 1.2.3", RubyConfig())
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("v1.2.2").Get())).IsFalse()
+			g.Assert(c.Check(String("v1.5.0").Get())).IsTrue()
+		})
+	})
+}
+
+func ExampleRubyConfig() {
+	c, err := ParseConstraint("~> 1.2", RubyConfig())
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(c.Check(String("v1.9.0").Get()))
+	fmt.Println(c.Check(String("v2.0.0").Get()))
+	// Output:
+	// true
+	// false
+}
@@ -0,0 +1,37 @@
+package semver
+
+import "regexp"
+
+/*
+pseudoRe matches the pre release of a Go module pseudo-version, which ends
+with a 14 digit UTC timestamp and a commit hash prefix. The timestamp follows
+a 0 identifier, except for the vX.0.0-yyyymmddhhmmss-abcdefabcdef form.
+*/
+var pseudoRe = regexp.MustCompile(`^((?:[0-9A-Za-z-]+\.)*0\.)?\d{14}-[0-9A-Za-z]+$`)
+
+/*
+IsPseudoVersion returns true if the version is a Go module pseudo-version,
+which identifies an untagged commit, in one of the forms:
+
+vX.0.0-yyyymmddhhmmss-abcdefabcdef
+
+vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef
+
+vX.Y.Z-0.yyyymmddhhmmss-abcdefabcdef
+
+Compare orders pseudo-versions by their base version and then their
+timestamp, as the go command does, since the fixed width timestamp identifier
+compares in time order. Versions which only differ by commit hash are ordered
+by the hash.
+*/
+func (v *Version) IsPseudoVersion() bool {
+	m := pseudoRe.FindStringSubmatch(v.preRelease)
+	if m == nil {
+		return false
+	}
+	// the timestamp without a 0 identifier is only used on a vX.0.0 base
+	if m[1] == "" {
+		return v.minor == 0 && v.patch == 0
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package semver
+
+import (
+	"testing"
+
+	. "github.com/franela/goblin"
+)
+
+func TestPseudoVersion(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Go module pseudo-versions", func() {
+		g.It("Should detect pseudo-versions", func() {
+			for _, s := range []string{
+				"v0.0.0-20210101120000-abcdef123456",
+				"v2.0.0-20191109021931-daa7c04131f5",
+				"v1.2.3-pre.0.20210101120000-abcdef123456",
+				"v1.2.4-0.20210101120000-abcdef123456",
+				"v1.2.4-0.20210101120000-abcdef123456+incompatible",
+			} {
+				g.Assert(String(s).Get().IsPseudoVersion()).IsTrue(s)
+			}
+		})
+
+		g.It("Should not detect other versions", func() {
+			for _, s := range []string{
+				"v1.2.3", "v1.2.3-rc.1", "v1.2.3-20210101120000-abcdef123456",
+				"v0.0.0-2021010112000-abcdef123456", "v1.2.4-1.20210101120000-abcdef123456",
+				"v0.0.0-20210101120000", "v1.2.4-0.20210101120000-abcdef_123456",
+			} {
+				g.Assert(String(s).Get().IsPseudoVersion()).IsFalse(s)
+			}
+		})
+
+		g.It("Should order pseudo-versions by timestamp", func() {
+			older := String("v0.0.0-20210101120000-ffffffffffff").Get()
+			newer := String("v0.0.0-20210102090000-000000000000").Get()
+			g.Assert(older.Compare(newer)).Equal(-1)
+			g.Assert(newer.Compare(older)).Equal(1)
+
+			older = String("v1.2.4-0.20191231235959-ffffffffffff").Get()
+			newer = String("v1.2.4-0.20200101000000-000000000000").Get()
+			g.Assert(older.Compare(newer)).Equal(-1)
+
+			older = String("v1.2.3-rc.1.0.20210101120000-abcdef123456").Get()
+			newer = String("v1.2.3-rc.1.0.20211201120000-abcdef123456").Get()
+			g.Assert(older.Compare(newer)).Equal(-1)
+		})
+
+		g.It("Should order pseudo-versions around their base versions", func() {
+			versions := getVersions(
+				"v1.2.4", "v1.2.4-0.20210301000000-abcdef123456", "v1.2.3",
+				"v1.2.4-0.20210101000000-abcdef123456", "v1.2.4-rc.1",
+			)
+			Sort(versions)
+			g.Assert(versionStrings(versions)).Equal([]string{
+				"v1.2.3", "v1.2.4-0.20210101000000-abcdef123456",
+				"v1.2.4-0.20210301000000-abcdef123456", "v1.2.4-rc.1", "v1.2.4",
+			})
+		})
+	})
+}
@@ -0,0 +1,169 @@
+package semver
+
+/*
+interval is the range of versions allowed by a constraint clause, between an
+optional lower and upper limit. A nil limit is unbounded.
+*/
+type interval struct {
+	lower, upper       *Version
+	lowerInc, upperInc bool
+}
+
+/*
+Intersect returns a new Constraint which is satisfied only by versions which
+satisfy both the Constraint and the other Constraint, the same as And. Use
+IsEmpty on the result to detect two ranges which conflict.
+*/
+func (c *Constraint) Intersect(other *Constraint) *Constraint {
+	return c.And(other)
+}
+
+/*
+IsEmpty returns true if no version can satisfy the Constraint, such as
+>=2.0.0 <1.0.0, or the intersection of the adjacent ranges <1.2.0 and
+>=1.2.0.
+
+Each || group is reduced to the interval between its highest lower limit and
+lowest upper limit, and the Constraint is empty if every interval is empty. A
+!= clause only empties a group when the interval lies entirely within the
+excluded version or partial version. The pre release visibility rule is not
+considered, so a Constraint which only pre releases satisfy is not empty.
+*/
+func (c *Constraint) IsEmpty() bool {
+	for _, group := range c.groups {
+		if !groupEmpty(group) {
+			return false
+		}
+	}
+	return true
+}
+
+// groupEmpty returns true if no version can satisfy every clause.
+func groupEmpty(clauses []*Version) bool {
+	var in interval
+	var excluded []interval
+	for _, clause := range clauses {
+		r := clause.interval()
+		if clause.operator != "" && clause.operator == clause.conf().ops.NEQ {
+			excluded = append(excluded, r)
+			continue
+		}
+		in = in.intersect(r)
+	}
+
+	if in.empty() {
+		return true
+	}
+	for _, ex := range excluded {
+		if ex.contains(in) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+interval returns the range of versions allowed by the version Operator, as for
+opCompare. For a != Operator the excluded range is returned instead. A version
+with an unknown Operator allows nothing, and returns an empty interval.
+*/
+func (v *Version) interval() interval {
+	last := 2 - int(v.omitted)
+	ops := v.conf().ops
+
+	switch v.operator {
+	case "", ops.EQ, ops.EQ + ops.EQ, ops.NEQ:
+		if v.omitted > 0 {
+			return interval{lower: v, lowerInc: true, upper: v.bound(last)}
+		}
+		return interval{lower: v, lowerInc: true, upper: v, upperInc: true}
+	case ops.GTE:
+		return interval{lower: v, lowerInc: true}
+	case ops.GT:
+		if v.omitted > 0 {
+			return interval{lower: v.bound(last), lowerInc: true}
+		}
+		return interval{lower: v}
+	case ops.LTE:
+		if v.omitted > 0 {
+			return interval{upper: v.bound(last)}
+		}
+		return interval{upper: v, upperInc: true}
+	case ops.LT:
+		return interval{upper: v}
+	case ops.Caret:
+		return interval{lower: v, lowerInc: true, upper: v.bound(v.caretLevel())}
+	case ops.Tilde:
+		return interval{lower: v, lowerInc: true, upper: v.bound(v.tildeLevel())}
+	case ops.Pessimistic:
+		return interval{lower: v, lowerInc: true, upper: v.bound(v.pessimisticLevel())}
+	}
+
+	return interval{lower: v, upper: v}
+}
+
+// intersect returns the interval allowed by both intervals.
+func (r interval) intersect(o interval) interval {
+	if o.lower != nil {
+		c := 1
+		if r.lower != nil {
+			c = o.lower.Compare(r.lower)
+		}
+		switch {
+		case c > 0:
+			r.lower, r.lowerInc = o.lower, o.lowerInc
+		case c == 0:
+			r.lowerInc = r.lowerInc && o.lowerInc
+		}
+	}
+
+	if o.upper != nil {
+		c := -1
+		if r.upper != nil {
+			c = o.upper.Compare(r.upper)
+		}
+		switch {
+		case c < 0:
+			r.upper, r.upperInc = o.upper, o.upperInc
+		case c == 0:
+			r.upperInc = r.upperInc && o.upperInc
+		}
+	}
+
+	return r
+}
+
+// empty returns true if the interval allows no version.
+func (r interval) empty() bool {
+	if r.lower == nil || r.upper == nil {
+		return false
+	}
+	c := r.lower.Compare(r.upper)
+	return c > 0 || (c == 0 && !(r.lowerInc && r.upperInc))
+}
+
+// contains returns true if every version in the interval o is also in the
+// interval.
+func (r interval) contains(o interval) bool {
+	if r.lower != nil {
+		if o.lower == nil {
+			return false
+		}
+		c := r.lower.Compare(o.lower)
+		if c > 0 || (c == 0 && !r.lowerInc && o.lowerInc) {
+			return false
+		}
+	}
+
+	if r.upper != nil {
+		if o.upper == nil {
+			return false
+		}
+		c := r.upper.Compare(o.upper)
+		if c < 0 || (c == 0 && !r.upperInc && o.upperInc) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,72 @@
+package semver
+
+import (
+	"testing"
+
+	. "github.com/franela/goblin"
+)
+
+func TestIntersect(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Constraint intersection", func() {
+		g.It("Should check versions against both ranges", func() {
+			c := mustConstraint(">=1.0.0 <2.0.0").Intersect(mustConstraint("^1.5.0"))
+			g.Assert(c.IsEmpty()).IsFalse()
+			g.Assert(c.Check(String("v1.6.0").Get())).IsTrue()
+			g.Assert(c.Check(String("v1.4.0").Get())).IsFalse()
+		})
+
+		g.It("Should not be empty for overlapping ranges", func() {
+			for _, r := range [][2]string{
+				{">=1.0.0 <2.0.0", ">=1.5.0 <3.0.0"},
+				{"^1.2.0", "~1.4"},
+				{"<=1.2.0", ">=1.2.0"},
+				{"1.x", ">1.9.9"},
+				{"*", "<0.0.1"},
+				{">=1.0.0 <2.0.0 || >=3.0.0", "^3.1"},
+				{">1.1.9", "<1.2.0"},
+			} {
+				c := mustConstraint(r[0]).Intersect(mustConstraint(r[1]))
+				g.Assert(c.IsEmpty()).IsFalse(r[0] + " and " + r[1])
+			}
+		})
+
+		g.It("Should be empty for adjacent but disjoint ranges", func() {
+			for _, r := range [][2]string{
+				{"<1.2.0", ">=1.2.0"},
+				{"<=1.2.0", ">1.2.0"},
+				{"^1.0.0", ">=2.0.0-0"},
+				{"~1.2", "1.3.x"},
+				{"<=1.2", ">1.2"},
+			} {
+				c := mustConstraint(r[0]).Intersect(mustConstraint(r[1]))
+				g.Assert(c.IsEmpty()).IsTrue(r[0] + " and " + r[1])
+			}
+		})
+
+		g.It("Should be empty for fully disjoint ranges", func() {
+			for _, r := range [][2]string{
+				{">=1.0.0 <2.0.0", ">=3.0.0"},
+				{"^1.2.3", "^2.0.0"},
+				{"1.2.3", "1.2.4"},
+				{"^1.0.0 || ^2.0.0", "^3.0.0 || <1.0.0"},
+			} {
+				c := mustConstraint(r[0]).Intersect(mustConstraint(r[1]))
+				g.Assert(c.IsEmpty()).IsTrue(r[0] + " and " + r[1])
+			}
+		})
+
+		g.It("Should be empty when a range is excluded by a != clause", func() {
+			g.Assert(mustConstraint("1.2.3 !=1.2.3").IsEmpty()).IsTrue()
+			g.Assert(mustConstraint("~1.2.3 !=1.2").IsEmpty()).IsTrue()
+			g.Assert(mustConstraint("~1.2 !=1.2.3").IsEmpty()).IsFalse()
+			g.Assert(mustConstraint(">=1.2.0 !=1.2.0").IsEmpty()).IsFalse()
+		})
+
+		g.It("Should be empty for a reversed range", func() {
+			g.Assert(mustConstraint(">=2.0.0 <1.0.0").IsEmpty()).IsTrue()
+			g.Assert(mustConstraint(">=1.0.0 <1.0.0").IsEmpty()).IsTrue()
+			g.Assert(mustConstraint(">=1.0.0 <=1.0.0").IsEmpty()).IsFalse()
+		})
+	})
+}
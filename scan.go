@@ -0,0 +1,106 @@
+package semver
+
+/*
+scanVersion is a fast path for the default config regex, which walks the
+string s once and returns the same submatches as FindStringSubmatch.
+
+It only handles the common shape of a version string, with a known default
+operator, and pre release and build metadata made of period separated
+identifiers of ASCII alphanumerics and hyphens. For anything else ok is false, and the
+caller falls back to the regex, which remains the authority on the grammar.
+*/
+func scanVersion(s string) (parts []string, ok bool) {
+	parts = make([]string, 8)
+	parts[0] = s
+
+	i := 0
+	if len(s) > 1 && (s[0] == '>' || s[0] == '<' || s[0] == '!' || s[0] == '=') && s[1] == '=' {
+		i = 2
+	} else if len(s) > 0 && (s[0] == '>' || s[0] == '<' || s[0] == '=' || s[0] == '^' || s[0] == '~') {
+		i = 1
+	}
+	parts[1] = s[:i]
+
+	if i < len(s) && s[i] == 'v' {
+		parts[2] = "v"
+		i++
+	}
+
+	j := scanDigits(s, i)
+	if j == i {
+		return nil, false
+	}
+	parts[3] = s[i:j]
+	i = j
+
+	// minor and patch versions
+	for k := 4; k <= 5 && i < len(s) && s[i] == '.'; k++ {
+		j = scanDigits(s, i+1)
+		if j == i+1 {
+			if j == len(s) || (s[j] != 'x' && s[j] != 'X' && s[j] != '*') {
+				return nil, false
+			}
+			j++
+		}
+		parts[k] = s[i+1 : j]
+		i = j
+	}
+
+	if i < len(s) && s[i] == '-' {
+		j = scanIdentifiers(s, i+1)
+		if j == i+1 {
+			return nil, false
+		}
+		parts[6] = s[i+1 : j]
+		i = j
+	}
+
+	if i < len(s) && s[i] == '+' {
+		j = scanIdentifiers(s, i+1)
+		if j == i+1 {
+			return nil, false
+		}
+		parts[7] = s[i+1 : j]
+		i = j
+	}
+
+	if i != len(s) {
+		return nil, false
+	}
+	return parts, true
+}
+
+// scanDigits returns the index after the run of ASCII digits starting at i.
+func scanDigits(s string, i int) int {
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+/*
+scanIdentifiers returns the index after the run of period separated
+identifiers starting at i, where each identifier is a non empty run of ASCII
+alphanumerics and hyphens. A run with an empty identifier returns i.
+*/
+func scanIdentifiers(s string, i int) int {
+	start, empty := i, true
+	for i < len(s) {
+		c := s[i]
+		if c == '.' {
+			if empty {
+				return start
+			}
+			empty = true
+		} else if c == '-' || (c >= '0' && c <= '9') || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') {
+			empty = false
+		} else {
+			break
+		}
+		i++
+	}
+	if empty {
+		return start
+	}
+	return i
+}
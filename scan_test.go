@@ -0,0 +1,106 @@
+package semver
+
+import (
+	"testing"
+
+	. "github.com/franela/goblin"
+)
+
+// scanCorpus is a set of version strings covering the default grammar.
+var scanCorpus = []string{
+	"1.2.3", "v1.2.3", ">=v1.2.3", "<=1.2.3", ">1.2.3", "<1.2.3", "^1.2.3",
+	"~1.2.3", "v0.0.0", "18446744073709551615.0.0", "01.02.03", "1.2",
+	"~1", "^1.x", "~1.2.X", "1.*", "1.x.3", "v1.0.0-alpha", "1.0.0-alpha.1",
+	"1.0.0-0.3.7", "1.0.0-x.7.z.92", "1.0.0-x-y-z.--", "1.0.0-alpha+001",
+	"1.0.0+20130313144700", "1.0.0-beta+exp.sha.5114f85", "1.0.0+21AF26D3----117B344092BD",
+	"v1.2.3-rc.1+build.5", ">=v1.2.3-pre+meta", "1.2.3-a|b", "1.2.3+a|b",
+	"1.2.3-.alpha", "1.2.3-a..b", "1.2.3meta", "1.2.3.4", "1.2.3-", "1.2.3+",
+	"1.2.3-rc+", "1.2.3_rc", "1.0.0-a_b", ">>1.2.3", "||1.2.3", "=1.2.3",
+	"vv1.2.3", "V1.2.3", "1..2", "1.", "", "v", ">=", "nosemver", " 1.2.3",
+	"1.2.3 ", "1.2.3\n", "1.2x", "1.xx", "~>1.2", "^=1.2.3",
+	"!=1.2.3", "!=v1.2", "!1.2.3", "!==1.2.3", "==1.2.3", "===1.2.3", "=>1.2.3",
+}
+
+func TestScanVersion(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version scanner fast path", func() {
+		g.It("Should produce the same submatches as the regex for a corpus", func() {
+			for _, s := range scanCorpus {
+				parts, ok := scanVersion(s)
+				if ok {
+					g.Assert(parts).Equal(defaultConf.re.FindStringSubmatch(s), s)
+				}
+			}
+		})
+
+		g.It("Should produce the same submatches as the regex for generated strings", func() {
+			alphabet := []byte("1.-+xv>=a0!")
+			var gen func(prefix []byte, n int)
+			gen = func(prefix []byte, n int) {
+				s := string(prefix)
+				if parts, ok := scanVersion(s); ok {
+					g.Assert(parts).Equal(defaultConf.re.FindStringSubmatch(s), s)
+				}
+				if n == 0 {
+					return
+				}
+				for _, c := range alphabet {
+					gen(append(prefix, c), n-1)
+				}
+			}
+			gen(nil, 5)
+		})
+
+		g.It("Should handle common versions without the regex", func() {
+			for _, s := range []string{
+				"1.2.3", "v1.2.3", ">=v1.2.3", "^1.2", "~1.x", "1.0.0-rc.1",
+				"1.0.0-beta+exp.sha.5114f85", "1.0.0-x-y-z", "1.0.0+build",
+				"1.0.0-x-y-z.--", "1.2.3--", "1.0.0+21AF26D3----117B344092BD",
+			} {
+				_, ok := scanVersion(s)
+				g.Assert(ok).IsTrue(s)
+			}
+		})
+
+		g.It("Should reject empty identifiers and invalid characters", func() {
+			for _, s := range []string{
+				"1.0.0-.alpha", "1.0.0-a..b", "1.0.0-a.", "1.0.0-a|b", "1.0.0+a|b", "1.0.0+.b",
+			} {
+				_, ok := scanVersion(s)
+				g.Assert(ok).IsFalse(s)
+				g.Assert(defaultConf.re.MatchString(s)).IsFalse(s)
+			}
+		})
+
+		g.It("Should parse the same versions with and without the fast path", func() {
+			regex := *defaultConf
+			regex.scan = false
+			for _, s := range scanCorpus {
+				v, err := String(s).Parse()
+				rv, rerr := String(s).Parse(&regex)
+				g.Assert(err == nil).Equal(rerr == nil, s)
+				if err == nil {
+					g.Assert(string(v.ToString())).Equal(string(rv.ToString()), s)
+				} else {
+					g.Assert(err.Error()).Equal(rerr.Error(), s)
+				}
+			}
+		})
+	})
+}
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		String(">=v1.2.3-rc.1+build.5").Parse()
+	}
+}
+
+func BenchmarkParseRegex(b *testing.B) {
+	regex := *defaultConf
+	regex.scan = false
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		String(">=v1.2.3-rc.1+build.5").Parse(&regex)
+	}
+}
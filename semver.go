@@ -14,8 +14,26 @@ method:
 
 < - Less than.
 
+!= - Not equal to.
+
+= - Equal to, which is the same as no operator. The == form is also accepted.
+
+^ - Compatible with, following the npm caret range rules.
+
+~ - Approximately equivalent to, following the npm tilde range rules.
+
+Versions with an Operator may be partial, such as ^1.2 or ~1, in which case
+the omitted version numbers are treated as wildcards. An omitted version
+number can also be written as an x, X, or * wildcard, such as ^1.x.
+
 The syntax of the comparison operators can be customized with the Operators
 struct and Config method.
+
+Multiple operator rules can be combined into a range with a Constraint, see
+ParseConstraint.
+
+A config and the versions parsed with it are never modified after they are
+created, so parsing and comparing versions is safe for concurrent use.
 */
 package semver
 
@@ -24,23 +42,44 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"unicode"
 )
 
 // See https://regex101.com/r/CkWF3o/1 for regex testing.
-var opRe string = `[>|<]+=?`
-var semverRe string = `(?:v)?([\d]+).([\d]+).([\d]+)(?:-((?:[.|-]?[\d\w]+)+))?(?:\+)?((?:[.|-]?[\d\w]+)+)?`
-var re *regexp.Regexp = regexp.MustCompile(fmt.Sprintf("(?m)^(%s)?%s$", opRe, semverRe))
+var opRe string = `[>|<]+=?|!=|==?|\^|~`
+var coreRe string = `(v)?([\d]+)(?:\.([\d]+|[xX*]))?(?:\.([\d]+|[xX*]))?`
+var extRe string = `(?:-(` + identsRe + `))?(?:\+(` + identsRe + `))?`
+
+// identsRe matches a period separated list of pre release or build metadata
+// identifiers, which are made of ASCII alphanumerics and hyphens.
+var identsRe string = `[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*`
+var semverRe string = coreRe + extRe
 
-var defaultConf *config = &config{
-	ops: &Operators{
-		GT:  Operator(">"),
-		GTE: Operator(">="),
-		LT:  Operator("<"),
-		LTE: Operator("<="),
-	},
-	re: re,
+// epochRe matches the optional epoch enabled with the WithEpoch Option.
+var epochRe string = `(?:([\d]+):)?`
+
+// buildRe matches the optional fourth version number enabled with the
+// WithBuildNumber Option.
+var buildRe string = `(?:\.([\d]+))?`
+
+var defaultOps Operators = Operators{
+	GT:    Operator(">"),
+	GTE:   Operator(">="),
+	LT:    Operator("<"),
+	LTE:   Operator("<="),
+	EQ:    Operator("="),
+	NEQ:   Operator("!="),
+	Caret: Operator("^"),
+	Tilde: Operator("~"),
 }
 
+var defaultConf *config = Config(defaultOps, opRe)
+
+// globalConf is the config set with SetDefaultConfig, which replaces
+// defaultConf when it is not nil.
+var globalConf atomic.Pointer[config]
+
 // Operators defines a set of operator syntax for semantic version comparisons.
 type Operators struct {
 	// GT is a greater than Operator.
@@ -51,11 +90,190 @@ type Operators struct {
 	LT Operator
 	// LTE is a less than or equal to Operator.
 	LTE Operator
+	// EQ is an explicit equal to Operator, which behaves the same as a version
+	// with no Operator. The EQ Operator written twice, such as ==, is accepted
+	// as the same Operator.
+	EQ Operator
+	// NEQ is a not equal to Operator, which allows any version without equal
+	// precedence, or outside the range of a partial version.
+	NEQ Operator
+	// Caret is a compatible with Operator, which allows changes that do not
+	// modify the left-most non-zero version number.
+	Caret Operator
+	// Tilde is an approximately equivalent to Operator, which allows patch
+	// level changes, or minor level changes when only a major version is
+	// specified.
+	Tilde Operator
+	// Pessimistic is a Rubygems style pessimistic Operator, which allows
+	// changes to only the last specified version number.
+	Pessimistic Operator
 }
 
+/*
+config is the Operators and regex used to parse and compare versions. A config
+is never modified after it is created, so it is safe to share between
+goroutines and versions.
+*/
 type config struct {
-	ops *Operators
-	re  *regexp.Regexp
+	ops  Operators
+	re   *regexp.Regexp
+	opRe *regexp.Regexp
+	// prefix is written before the version numbers by Version.String.
+	prefix string
+	// strict rejects the v prefix and partial versions when parsing.
+	strict bool
+	// requirePrefix rejects a version without the v prefix when parsing.
+	requirePrefix bool
+	// foldCase compares alphanumeric pre release identifiers case
+	// insensitively.
+	foldCase bool
+	// lowerPreRelease lowercases the pre release when parsing.
+	lowerPreRelease bool
+	// scan enables the scanVersion fast path, which is only equivalent to the
+	// regex for the default operator regex.
+	scan bool
+	// hidePreReleases only allows a pre release version to satisfy an
+	// Operator which has a pre release on the same major, minor, and patch
+	// version.
+	hidePreReleases bool
+	// buildNumber enables a fourth version number after the patch version.
+	buildNumber bool
+	// epoch enables an epoch before the version.
+	epoch bool
+	// includePreReleases lets a pre release version satisfy a Constraint
+	// without a pre release on the same major, minor, and patch version.
+	includePreReleases bool
+}
+
+/*
+Option is an optional setting which can be passed to Config or DefaultConfig.
+*/
+type Option func(*config)
+
+/*
+WithPrefix sets the prefix written before the version numbers by
+Version.String, which is "v" by default. Go modules use the v prefix, while
+many other ecosystems expect no prefix, for example:
+
+String("v1.2.3").Get(DefaultConfig(WithPrefix(""))).String() // 1.2.3
+
+The prefix does not affect parsing, where the "v" is always optional.
+Version.ToString keeps the prefix of a parsed input instead, so with the
+config above >=v1.2.3 is written as >=v1.2.3 by ToString, but as 1.2.3 by
+String. Versions which were not parsed, such as from NewVersion, use the
+prefix with both.
+*/
+func WithPrefix(prefix string) Option {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+/*
+Strict enables exact https://semver.org compliance when parsing with
+String.Parse, String.Get, or String.IsValid. The leading "v" is rejected, all of the major, minor, and patch
+version numbers are required even with an Operator, and numeric identifiers
+must not have leading zeros. Constraints may still contain partial versions.
+*/
+func Strict() Option {
+	return func(c *config) {
+		c.strict = true
+	}
+}
+
+/*
+RequirePrefix rejects a version without the leading "v" when parsing, as
+used by Go modules, so String.Parse returns an error for 1.2.3 but accepts
+v1.2.3. It applies to every version parsed with the config, including the
+clauses of a Constraint, and cannot be combined with Strict, which rejects the
+prefix.
+*/
+func RequirePrefix() Option {
+	return func(c *config) {
+		c.requirePrefix = true
+	}
+}
+
+/*
+CaseInsensitive enables case insensitive comparison of alphanumeric pre
+release identifiers, so v1.0.0-RC.1 and v1.0.0-rc.1 have equal precedence. By
+default identifiers are compared in ASCII sort order, where uppercase letters
+are lower than lowercase letters. The Option applies to comparisons where
+either version was parsed with the config, so the result does not depend on
+the order of the versions.
+*/
+func CaseInsensitive() Option {
+	return func(c *config) {
+		c.foldCase = true
+	}
+}
+
+/*
+LowercasePrerelease lowercases the pre release identifiers of a version when
+parsing, so inputs with inconsistent casing such as v1.0.0-RC1 and
+v1.0.0-rc1 are stored, written, and compared as v1.0.0-rc1. Numeric
+identifiers are unaffected, and build metadata is left as it was written.
+*/
+func LowercasePrerelease() Option {
+	return func(c *config) {
+		c.lowerPreRelease = true
+	}
+}
+
+/*
+IncludePrerelease disables the npm pre release visibility rule of
+Constraint.Check, so pre release versions satisfy a Constraint like any other
+version, and v1.2.3-beta satisfies >=1.0.0. It also disables the rule for
+Version.OpCompare with the NpmConfig.
+*/
+func IncludePrerelease() Option {
+	return func(c *config) {
+		c.includePreReleases = true
+		c.hidePreReleases = false
+	}
+}
+
+/*
+HidePrerelease applies the npm pre release visibility rule of Constraint.Check
+to Version.OpCompare, so a pre release version only satisfies an Operator
+which has a pre release on the same major, minor, and patch version. For
+example v2.0.0-beta no longer satisfies >=v1.0.0, but v1.0.0-beta still
+satisfies >=v1.0.0-alpha. It is enabled by the NpmConfig, and is off by
+default.
+*/
+func HidePrerelease() Option {
+	return func(c *config) {
+		c.hidePreReleases = true
+	}
+}
+
+/*
+WithBuildNumber enables an optional fourth version number after the patch
+version, such as the 4 in 1.2.3.4, as used by Microsoft and some Java tooling.
+It is available from Version.Build, written by Version.String, and compared
+after the patch version. A version without the fourth number has a build
+number of zero, so 1.2.3 is equal to 1.2.3.0.
+
+Operator ranges treat the build number as part of the patch version, so
+~1.2.3.4 allows >=1.2.3.4 <1.3.0.
+*/
+func WithBuildNumber() Option {
+	return func(c *config) {
+		c.buildNumber = true
+	}
+}
+
+/*
+WithEpoch enables an optional epoch before the version, such as the 1 in
+1:2.3.4, as used by Debian and Arch Linux package versions. It is available
+from Version.Epoch, and has the highest precedence in comparisons, so
+1:0.1.0 is greater than 2.0.0. A version without an epoch has an epoch of
+zero, and Version.String only writes a non-zero epoch.
+*/
+func WithEpoch() Option {
+	return func(c *config) {
+		c.epoch = true
+	}
 }
 
 /*
@@ -65,13 +283,122 @@ method and define custom operator syntax and regex.
 The regex string to parse the operators is combined with the semver regex. An invalid
 regex string will result in a panic.
 */
-func Config(ops Operators, regex string) *config {
+func Config(ops Operators, regex string, opts ...Option) *config {
 	regex = strings.TrimPrefix(regex, "^")
 	regex = strings.TrimSuffix(regex, "$")
-	return &config{
-		ops: &ops,
-		re:  regexp.MustCompile(fmt.Sprintf("(?m)^(%s)?%s$", regex, semverRe)),
+	c := &config{
+		ops:    ops,
+		prefix: "v",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	version := semverRe
+	if c.buildNumber {
+		version = coreRe + buildRe + extRe
+	}
+	if c.epoch {
+		version = epochRe + version
+	}
+	c.re = regexp.MustCompile(fmt.Sprintf("^(%s)?%s$", regex, version))
+	c.opRe = regexp.MustCompile(fmt.Sprintf("^(?:%s)$", regex))
+	c.scan = regex == opRe && !c.buildNumber && !c.epoch
+	return c
+}
+
+/*
+ConfigE is Config, but returns an error rather than a config which parses
+surprising results. An error is returned if the regex is invalid or has a
+capturing group, which would shift the version submatches, if two Operators
+share the same syntax, or if an Operator is not matched by the regex and so
+can never be parsed.
+*/
+func ConfigE(ops Operators, regex string, opts ...Option) (*config, error) {
+	trimmed := strings.TrimSuffix(strings.TrimPrefix(regex, "^"), "$")
+	re, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", trimmed))
+	if err != nil {
+		return nil, fmt.Errorf("invalid operator regex %q: %w", regex, err)
+	}
+	// a capturing group would shift the version submatches of the full regex
+	if re.NumSubexp() > 0 {
+		return nil, fmt.Errorf("invalid operator regex %q: capturing groups are not allowed, use (?:...)", regex)
+	}
+
+	c := Config(ops, regex, opts...)
+	if err := c.validateOps(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// validateOps checks that the set Operators are unique and matched by the
+// operator regex.
+func (c *config) validateOps() error {
+	named := []struct {
+		name string
+		op   Operator
+	}{
+		{"GT", c.ops.GT}, {"GTE", c.ops.GTE}, {"LT", c.ops.LT}, {"LTE", c.ops.LTE},
+		{"EQ", c.ops.EQ}, {"NEQ", c.ops.NEQ}, {"Caret", c.ops.Caret},
+		{"Tilde", c.ops.Tilde}, {"Pessimistic", c.ops.Pessimistic},
+	}
+
+	seen := map[Operator]string{}
+	for _, n := range named {
+		if n.op == "" {
+			continue
+		}
+		if prev, ok := seen[n.op]; ok {
+			return fmt.Errorf("invalid operators: %s and %s are both %q", prev, n.name, string(n.op))
+		}
+		seen[n.op] = n.name
+		if !c.opRe.MatchString(string(n.op)) {
+			return fmt.Errorf("invalid operators: %s %q is not matched by the regex", n.name, string(n.op))
+		}
+	}
+
+	return nil
+}
+
+/*
+DefaultConfig returns a config with the default operators and the options
+applied.
+*/
+func DefaultConfig(opts ...Option) *config {
+	return Config(defaultOps, opRe, opts...)
+}
+
+/*
+SetDefaultConfig replaces the package default config, which is used by every
+subsequent parse, comparison, and constructor when no config is passed, for an
+application which always uses custom operator syntax. Versions parsed before
+the call keep the config they were parsed with. Passing nil restores the
+built-in default config.
+
+It is safe to call concurrently with parsing, though it is intended to be
+called once during program initialization.
+*/
+func SetDefaultConfig(c *config) {
+	globalConf.Store(c)
+}
+
+// currentConfig returns the config set with SetDefaultConfig, or the built-in
+// default config.
+func currentConfig() *config {
+	if c := globalConf.Load(); c != nil {
+		return c
+	}
+	return defaultConf
+}
+
+// getConfig returns the first non-nil config passed to a variadic config
+// param, or the default config.
+func getConfig(conf []*config) *config {
+	if len(conf) > 0 && conf[0] != nil {
+		return conf[0]
 	}
+	return currentConfig()
 }
 
 /*
@@ -79,6 +406,29 @@ Operator is a comparison operator to be applied to a version.
 */
 type Operator string
 
+/*
+ParseOperator returns the leading Operator token of s, which is everything
+before the version, such as >= in both >= and >=1.2.3. Surrounding whitespace
+is ignored. The bool is false if there is no leading token, or the token is
+not one of the Operators of the config, such as => with the default config.
+*/
+func ParseOperator(s string, conf ...*config) (Operator, bool) {
+	set := getConfig(conf)
+	s = strings.TrimSpace(s)
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) || r == '*'
+	})
+	if end < 0 {
+		end = len(s)
+	}
+
+	op := s[:end]
+	if op == "" || !set.opRe.MatchString(op) || !set.ops.has(Operator(op)) {
+		return "", false
+	}
+	return Operator(op), true
+}
+
 /*
 String is a semantic version string with additional support for
 an optional comparison Operator. For example:
@@ -112,36 +462,78 @@ type Version struct {
 	// operator is an optional value for the set of comparison operators for the
 	// version. See the semver.Operators for more info.
 	operator Operator
+	// epoch is the optional epoch enabled with the WithEpoch Option, which
+	// has higher precedence than the major version.
+	epoch uint64
 	// major is the semantic major release version number. Must be a positive
 	// integer.
-	major uint16
+	major uint64
 	// minor is the semantic minor release version number. Must be a positive
 	// integer.
-	minor uint16
+	minor uint64
 	// patch is the semantic patch release version number. Must be a positive
 	// integer.
-	patch uint16
+	patch uint64
+	// build is the optional fourth version number enabled with the
+	// WithBuildNumber Option.
+	build uint64
 	// preRelease is the string data contained after the '-' in a semantic
 	// version string, but before the '+' denoting BuildMetadata. Can contain
 	// only alphanumeric characters separated by '-' or '.'.
 	preRelease string
+	// preReleaseParts is the pre release split into its period separated
+	// identifiers, cached when the version is created so comparisons do not
+	// need to split the pre release again. It is nil when there is no pre
+	// release.
+	preReleaseParts []string
 	// buildMetadata is the string data after the '+' character in a semantic
 	// version string. It can contain only alphanumeric characters separated by
 	// a '-' or '.', and is not factored into version comparisons.
 	buildMetadata string
+	// omitted is the number of trailing version numbers left out of a partial
+	// version, such as 1 for ~1.2. Only versions with an Operator can be
+	// partial.
+	omitted uint8
+	// inputPrefix is the "v" prefix of a parsed version string, or empty if it
+	// had none, which ToString writes when keepPrefix is true.
+	inputPrefix string
+	// keepPrefix is true for a version parsed from a String, so ToString
+	// reproduces the prefix of the input rather than the config prefix.
+	keepPrefix bool
 	// config is the Operators and Regex configuration to use for version comparison
 	// operators
 	config *config
 }
 
-// Major returns the semantic major version number as an int.
-func (v *Version) Major() int {
-	return int(v.major)
+// conf returns the version config, or the default config for a version
+// created without one.
+func (v *Version) conf() *config {
+	if v.config == nil {
+		return currentConfig()
+	}
+	return v.config
+}
+
+// Epoch returns the epoch as a uint64, which is always zero unless the version
+// was parsed with the WithEpoch Option.
+func (v *Version) Epoch() uint64 {
+	return v.epoch
 }
 
-// Minor returns the semantic minor version number as an int.
-func (v *Version) Minor() int {
-	return int(v.minor)
+// Build returns the fourth version number as a uint64, which is always zero
+// unless the version was parsed with the WithBuildNumber Option.
+func (v *Version) Build() uint64 {
+	return v.build
+}
+
+// Major returns the semantic major version number as a uint64.
+func (v *Version) Major() uint64 {
+	return v.major
+}
+
+// Minor returns the semantic minor version number as a uint64.
+func (v *Version) Minor() uint64 {
+	return v.minor
 }
 
 // Operator returns any set Operator as a string.
@@ -149,9 +541,9 @@ func (v *Version) Operator() string {
 	return string(v.operator)
 }
 
-// Patch returns the semantic patch version as an int.
-func (v *Version) Patch() int {
-	return int(v.patch)
+// Patch returns the semantic patch version as a uint64.
+func (v *Version) Patch() uint64 {
+	return v.patch
 }
 
 // PreRelease returns semantic version pre release data as a string
@@ -164,6 +556,18 @@ func (v *Version) PreRelease() string {
 	return v.preRelease
 }
 
+// PreReleaseIdentifiers returns the period separated identifiers of the pre
+// release data, or an empty slice if there is no pre release.
+func (v *Version) PreReleaseIdentifiers() []string {
+	if v.preRelease == "" {
+		return []string{}
+	}
+	if v.preReleaseParts != nil {
+		return append([]string{}, v.preReleaseParts...)
+	}
+	return strings.Split(v.preRelease, ".")
+}
+
 // Metadata returns semantic version build metadata as a string.
 //
 // Build metadata can contain any alphanumeric characters
@@ -173,20 +577,136 @@ func (v *Version) Metadata() string {
 	return v.buildMetadata
 }
 
-// ToString returns the semver.String for the version.
+// MetadataIdentifiers returns the period separated identifiers of the build
+// metadata, or an empty slice if there is no build metadata.
+func (v *Version) MetadataIdentifiers() []string {
+	if v.buildMetadata == "" {
+		return []string{}
+	}
+	return strings.Split(v.buildMetadata, ".")
+}
+
+// IsZero returns true if the version is v0.0.0 with no Operator, pre release,
+// or build metadata, which is also the result of String.Get with an invalid
+// String.
+func (v *Version) IsZero() bool {
+	return v.epoch == 0 && v.major == 0 && v.minor == 0 && v.patch == 0 && v.build == 0 &&
+		v.operator == "" && v.preRelease == "" && v.buildMetadata == ""
+}
+
+/*
+ToString returns the semver.String for the version, including any Operator.
+A version parsed from a String keeps the "v" prefix of its input, so 1.2.3
+round trips as 1.2.3 and v1.2.3 as v1.2.3, while any other version is written
+with the config prefix as with String.
+*/
 func (v *Version) ToString() String {
+	prefix := v.conf().prefix
+	if v.keepPrefix {
+		prefix = v.inputPrefix
+	}
+
 	var s strings.Builder
 	s.WriteString(string(v.operator))
-	s.WriteString(v.String())
+	s.WriteString(v.format(prefix))
+	return String(s.String())
+}
+
+/*
+Normalize returns the canonical String for the version, which is the same for
+any input that parses to the same version. The canonical form always has a
+"v" prefix regardless of the WithPrefix Option, drops the Operator, and writes
+every version number, so a partial ~1.2 normalizes to v1.2.0. Leading zeros
+tolerated by String.Get are dropped from the version numbers. The pre release
+and build metadata identifiers are kept as they are, including their case.
+*/
+func (v *Version) Normalize() String {
+	var s strings.Builder
+	if v.epoch > 0 {
+		s.WriteString(fmt.Sprintf("%v:", v.epoch))
+	}
+	s.WriteString("v")
+	s.WriteString(fmt.Sprintf("%v.%v.%v", v.major, v.minor, v.patch))
+	if v.conf().buildNumber {
+		s.WriteString(fmt.Sprintf(".%v", v.build))
+	}
+	if v.preRelease != "" {
+		s.WriteString("-")
+		s.WriteString(v.preRelease)
+	}
+	if v.buildMetadata != "" {
+		s.WriteString("+")
+		s.WriteString(v.buildMetadata)
+	}
 	return String(s.String())
 }
 
+/*
+Key returns a canonical precedence key for the version, which is equal for two
+versions exactly when Compare returns 0, and can be used as a map key. Unlike
+a Version, the key does not depend on the config pointer. Keys are only
+comparable between versions which agree on the CaseInsensitive Option.
+
+The key holds any non-zero epoch, the major, minor, and patch versions, any
+non-zero build number, and the pre release, such as v1.2.3-rc.1. The Operator
+and build metadata are excluded, so v1.2.3+a and v1.2.3+b have the same key.
+Numeric pre release identifiers are written without leading zeros, and
+alphanumeric identifiers are lowercased when the config uses the
+CaseInsensitive Option.
+*/
+func (v *Version) Key() string {
+	var s strings.Builder
+	if v.epoch > 0 {
+		s.WriteString(fmt.Sprintf("%d:", v.epoch))
+	}
+	s.WriteString(fmt.Sprintf("v%d.%d.%d", v.major, v.minor, v.patch))
+	if v.build > 0 {
+		s.WriteString(fmt.Sprintf(".%d", v.build))
+	}
+	fold := v.conf().foldCase
+	for i, id := range v.PreReleaseIdentifiers() {
+		if i == 0 {
+			s.WriteByte('-')
+		} else {
+			s.WriteByte('.')
+		}
+
+		switch {
+		case isNumeric(id):
+			if id = strings.TrimLeft(id, "0"); id == "" {
+				id = "0"
+			}
+		case fold:
+			id = strings.ToLower(id)
+		}
+		s.WriteString(id)
+	}
+	return s.String()
+}
+
 // String returns the version in semantic version string format.
 //
 // v{Major}.{Minor}.{Patch}-{PreRelease}+{BuildMetadata}
+//
+// The leading "v" can be changed with the WithPrefix Option, and the build
+// number of the WithBuildNumber Option is written after the patch version. A
+// non-zero epoch of the WithEpoch Option is written first, as in 1:v2.3.4.
 func (v *Version) String() string {
+	return v.format(v.conf().prefix)
+}
+
+// format writes the version like String, with the prefix before the version
+// numbers.
+func (v *Version) format(prefix string) string {
 	var s strings.Builder
-	s.WriteString(fmt.Sprintf("v%v.%v.%v", v.major, v.minor, v.patch))
+	if v.epoch > 0 {
+		s.WriteString(fmt.Sprintf("%v:", v.epoch))
+	}
+	s.WriteString(prefix)
+	s.WriteString(fmt.Sprintf("%v.%v.%v", v.major, v.minor, v.patch))
+	if v.conf().buildNumber {
+		s.WriteString(fmt.Sprintf(".%v", v.build))
+	}
 	if v.preRelease != "" {
 		s.WriteString("-")
 		s.WriteString(v.preRelease)
@@ -198,43 +718,256 @@ func (v *Version) String() string {
 	return s.String()
 }
 
+/*
+Format implements fmt.Formatter for the version with the following verbs:
+
+%v, %s - The version String, such as v1.2.3-rc.1.
+
+%+v - The version with its Operator, as ToString, such as >=v1.2.3-rc.1.
+
+%#v - The version fields in Go syntax, such as
+semver.Version{operator:">=", epoch:0, major:1, minor:2, patch:3, build:0, preRelease:"rc.1", buildMetadata:""}.
+
+%q - The quoted version String, such as "v1.2.3-rc.1".
+
+Width and alignment flags are applied to the version string as for %s.
+*/
+func (v *Version) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, "semver.Version{operator:%q, epoch:%d, major:%d, minor:%d, patch:%d, build:%d, preRelease:%q, buildMetadata:%q}",
+			string(v.operator), v.epoch, v.major, v.minor, v.patch, v.build, v.preRelease, v.buildMetadata)
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), string(v.ToString()))
+	case verb == 'v' || verb == 's' || verb == 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), v.String())
+	default:
+		fmt.Fprintf(f, "%%!%c(semver.Version=%s)", verb, v.String())
+	}
+}
+
 /*
 OpCompare tests any current version Operator against the version param and
 returns false if the passed version violates the Operator rule.
 
+A Caret Operator follows the npm caret range rules, where ^1.2.3 allows
+>=1.2.3 <2.0.0, ^0.2.3 allows >=0.2.3 <0.3.0, and ^0.0.3 allows >=0.0.3 <0.0.4.
+A Tilde Operator follows the npm tilde range rules, where ~1.2.3 allows
+>=1.2.3 <1.3.0, ~1.2 allows >=1.2.0 <1.3.0, and ~1 allows >=1.0.0 <2.0.0.
+A Pessimistic Operator follows the Rubygems rules, where ~>1.2 allows
+>=1.2.0 <2.0.0 and ~>1.2.3 allows >=1.2.3 <1.3.0. Pre releases of the
+exclusive upper bound are not allowed.
+
+Omitted version numbers in a partial version match any value, so >1.2
+allows >=1.3.0 and <=1.2 allows <1.3.0. A partial version with no Operator,
+which can only be parsed in a Constraint, allows any version it contains, so
+1.2.x allows >=1.2.0 <1.3.0. Wildcards combine with the Caret and Tilde
+Operators the same as omitted version numbers, so ^1.x allows >=1.0.0 <2.0.0,
+~1.2.x allows >=1.2.0 <1.3.0, and ^0.0.x allows >=0.0.0 <0.1.0.
+
 This can also produce a simple boolean result if the version operator
 is empty. An empty operator does an equality check on the two versions.
 
-Version Operators on the passed version param are ignored.
+By default a pre release version satisfies an Operator like any other
+version, so v2.0.0-beta satisfies >=v1.0.0. Use the HidePrerelease Option to
+apply the pre release visibility rule of Constraint.Check.
+
+Version Operators on the passed version param are ignored. False is returned
+if either version is nil.
 */
 func (v *Version) OpCompare(version *Version) bool {
+	if v == nil || version == nil {
+		return false
+	}
+	if v.conf().hidePreReleases && !preReleaseVisible([]*Version{v}, version) {
+		return false
+	}
+	return v.opCompare(version)
+}
+
+/*
+OpCompareE is OpCompare, but returns an error if the version Operator is not
+one of the Operators of the version config, such as a version parsed with a
+config whose regex matches more than its Operators. OpCompare returns false
+for an unknown Operator.
+*/
+func (v *Version) OpCompareE(version *Version) (bool, error) {
+	if v == nil {
+		return false, nil
+	}
+	if !v.conf().ops.has(v.operator) {
+		return false, fmt.Errorf("unknown operator %q for version %q", string(v.operator), v.String())
+	}
+	return v.OpCompare(version), nil
+}
+
+// has returns true if op is empty or one of the Operators.
+func (o Operators) has(op Operator) bool {
+	if op == "" || (o.EQ != "" && op == o.EQ+o.EQ) {
+		return true
+	}
+	for _, known := range []Operator{
+		o.GT, o.GTE, o.LT, o.LTE, o.EQ, o.NEQ, o.Caret, o.Tilde, o.Pessimistic,
+	} {
+		if op == known {
+			return true
+		}
+	}
+	return false
+}
+
+// opCompare is OpCompare without the pre release visibility rule.
+func (v *Version) opCompare(version *Version) bool {
 	i := v.Compare(version)
+	last := 2 - int(v.omitted)
+	ops := v.conf().ops
 
 	var t bool
 	switch v.operator {
-	case "":
-		t = i == 0
-	case v.config.ops.GTE:
+	case "", ops.EQ, ops.EQ + ops.EQ:
+		if v.omitted > 0 {
+			t = i <= 0 && v.bound(last).Compare(version) > 0
+		} else {
+			t = i == 0
+		}
+	case ops.GTE:
 		t = i <= 0
-	case v.config.ops.GT:
-		t = i < 0
-	case v.config.ops.LTE:
-		t = i >= 0
-	case v.config.ops.LT:
+	case ops.GT:
+		if v.omitted > 0 {
+			t = v.bound(last).Compare(version) <= 0
+		} else {
+			t = i < 0
+		}
+	case ops.LTE:
+		if v.omitted > 0 {
+			t = v.bound(last).Compare(version) > 0
+		} else {
+			t = i >= 0
+		}
+	case ops.LT:
 		t = i > 0
+	case ops.NEQ:
+		if v.omitted > 0 {
+			t = i > 0 || v.bound(last).Compare(version) <= 0
+		} else {
+			t = i != 0
+		}
+	case ops.Caret:
+		t = i <= 0 && v.bound(v.caretLevel()).Compare(version) > 0
+	case ops.Tilde:
+		t = i <= 0 && v.bound(v.tildeLevel()).Compare(version) > 0
+	case ops.Pessimistic:
+		t = i <= 0 && v.bound(v.pessimisticLevel()).Compare(version) > 0
 	}
 
 	return t
 }
 
+// boundParts is the cached pre release identifiers of a bound version.
+var boundParts = []string{"0"}
+
+/*
+bound returns the next release of the version at the level version number,
+where 0 is major, 1 is minor, and 2 is patch. The bound carries the lowest
+possible pre release so it can be used as an exclusive upper bound which also
+excludes pre releases of the bound itself.
+*/
+func (v *Version) bound(level int) *Version {
+	switch level {
+	case 0:
+		return &Version{epoch: v.epoch, major: v.major + 1, preRelease: "0", preReleaseParts: boundParts}
+	case 1:
+		return &Version{epoch: v.epoch, major: v.major, minor: v.minor + 1, preRelease: "0", preReleaseParts: boundParts}
+	default:
+		return &Version{epoch: v.epoch, major: v.major, minor: v.minor, patch: v.patch + 1, preRelease: "0", preReleaseParts: boundParts}
+	}
+}
+
+// caretLevel returns the level of the left-most non-zero version number, or
+// the last specified version number when all are zero.
+func (v *Version) caretLevel() int {
+	last := 2 - int(v.omitted)
+	for i, n := range []uint64{v.major, v.minor, v.patch}[:last+1] {
+		if n > 0 {
+			return i
+		}
+	}
+	return last
+}
+
+// tildeLevel returns the major level when only a major version is specified,
+// and the minor level otherwise.
+func (v *Version) tildeLevel() int {
+	if v.omitted == 2 {
+		return 0
+	}
+	return 1
+}
+
+// pessimisticLevel returns the level of the second to last specified version
+// number, or the major level when only a major version is specified.
+func (v *Version) pessimisticLevel() int {
+	if v.omitted >= 1 {
+		return 0
+	}
+	return 1
+}
+
 /*
 Compare checks the two versions and returns 1 if the current version is greater than
 the version param, -1 if the current version is less than the version param, and
 0 if they are equal.
 
 Comparison logic is implemented to the https://semver.org specification.
+
+A nil version is lower than any other version, and equal to another nil, so
+Compare can be called on a nil version.
 */
 func (v *Version) Compare(version *Version) int {
+	switch {
+	case v == nil && version == nil:
+		return 0
+	case v == nil:
+		return -1
+	case version == nil:
+		return 1
+	}
+
+	if i := v.CompareCore(version); i != 0 {
+		return i
+	}
+
+	return v.comparePreReleaseParts(version)
+}
+
+/*
+CompareCore checks only the major, minor, and patch versions of the two
+versions, and the epoch and build number of the WithEpoch and WithBuildNumber
+Options, and returns 1 if the current version is greater than the version
+param, -1 if the current version is less than the version param, and 0 if they
+are equal.
+
+Unlike Compare, pre release data is ignored, so v1.2.3-rc.1 and v1.2.3 are
+equal. As with Compare, a nil version is lower than any other version.
+*/
+func (v *Version) CompareCore(version *Version) int {
+	switch {
+	case v == nil && version == nil:
+		return 0
+	case v == nil:
+		return -1
+	case version == nil:
+		return 1
+	}
+
+	if v.epoch > version.epoch {
+		return 1
+	}
+
+	if v.epoch < version.epoch {
+		return -1
+	}
+
 	if v.major > version.major {
 		return 1
 	}
@@ -259,90 +992,836 @@ func (v *Version) Compare(version *Version) int {
 		return -1
 	}
 
-	return v.comparePreRelease(version.preRelease)
+	if v.build > version.build {
+		return 1
+	}
+
+	if v.build < version.build {
+		return -1
+	}
+
+	return 0
 }
 
 /*
-comparePreRelease is an internal method that evalutes only the current version
-pre release value against the preRelease param. Similar to Compare, it returns
-1 if the current version pre release is greater than the preRelease param, -1 if
-the current version pre release is less than the preRelease param, and 0 if they
-are equal.
+After returns true if the version has higher precedence than the version
+param, ignoring build metadata. It is equivalent to GreaterThan, and reads
+naturally in release gating conditionals such as:
 
-See https://semver.org/#spec-item-11 for more details on precedence with pre
+if current.After(deprecated) { ... }
+*/
+func (v *Version) After(version *Version) bool {
+	return v.Compare(version) > 0
+}
+
+/*
+Before returns true if the version has lower precedence than the version
+param, ignoring build metadata. It is equivalent to LessThan, and reads
+naturally in release gating conditionals such as:
+
+if current.Before(required) { ... }
+*/
+func (v *Version) Before(version *Version) bool {
+	return v.Compare(version) < 0
+}
+
+/*
+Equal returns true if the two versions are identical, including build
+metadata. Operators are ignored.
+
+Per the https://semver.org specification build metadata does not affect
+precedence, so v1.0.0+a and v1.0.0+b are not Equal, but do have equal
+precedence. Use EqualPrecedence to ignore build metadata.
+
+A nil version is only Equal to another nil.
+*/
+func (v *Version) Equal(version *Version) bool {
+	if v == nil || version == nil {
+		return v == version
+	}
+	return v.Compare(version) == 0 && v.buildMetadata == version.buildMetadata
+}
+
+/*
+EqualPrecedence returns true if the two versions have equal precedence
+following the https://semver.org specification, which ignores build metadata.
+It is equivalent to Compare returning 0.
+*/
+func (v *Version) EqualPrecedence(version *Version) bool {
+	return v.Compare(version) == 0
+}
+
+// GreaterThan returns true if the version has higher precedence than the
+// version param.
+func (v *Version) GreaterThan(version *Version) bool {
+	return v.Compare(version) > 0
+}
+
+// GreaterThanOrEqual returns true if the version has higher or equal
+// precedence to the version param.
+func (v *Version) GreaterThanOrEqual(version *Version) bool {
+	return v.Compare(version) >= 0
+}
+
+// LessThan returns true if the version has lower precedence than the version
+// param.
+func (v *Version) LessThan(version *Version) bool {
+	return v.Compare(version) < 0
+}
+
+// LessThanOrEqual returns true if the version has lower or equal precedence
+// to the version param.
+func (v *Version) LessThanOrEqual(version *Version) bool {
+	return v.Compare(version) <= 0
+}
+
+/*
+Between returns true if the version is within the inclusive range of the low
+and high versions by precedence. If low is greater than high the range is
+empty and false is returned.
+*/
+func (v *Version) Between(low, high *Version) bool {
+	return low.Compare(v) <= 0 && high.Compare(v) >= 0
+}
+
+/*
+Contains returns true if the version param falls within the range implied by
+the version, ignoring any Operator. A partial version from
+String.ParsePartial contains every version matching its omitted version
+numbers, so v1.2 contains v1.2.9 but not v1.3.0, as with the 1.2.x range of a
+Constraint. A full version only contains versions of equal precedence. False
+is returned if either version is nil.
+*/
+func (v *Version) Contains(version *Version) bool {
+	if v == nil || version == nil {
+		return false
+	}
+	r := *v
+	r.operator = ""
+	return r.opCompare(version)
+}
+
+/*
+Diff returns the most significant part of the version which differs from the
+version param, as one of "epoch", "major", "minor", "patch", "build", or
+"prerelease", where "epoch" and "build" are the epoch and build number of the
+WithEpoch and WithBuildNumber Options. An empty string is returned if the
+versions have equal precedence. Build metadata is ignored.
+
+Unlike Compare, Diff is not nil-safe, and both versions must be non-nil.
+*/
+func (v *Version) Diff(version *Version) string {
+	switch {
+	case v.epoch != version.epoch:
+		return "epoch"
+	case v.major != version.major:
+		return "major"
+	case v.minor != version.minor:
+		return "minor"
+	case v.patch != version.patch:
+		return "patch"
+	case v.build != version.build:
+		return "build"
+	case v.comparePreReleaseParts(version) != 0:
+		return "prerelease"
+	}
+	return ""
+}
+
+// SameMajor returns true if the versions have the same major version, and
+// the same epoch for versions parsed with the WithEpoch Option. False is
+// returned if either version is nil.
+func (v *Version) SameMajor(version *Version) bool {
+	if v == nil || version == nil {
+		return false
+	}
+	return v.epoch == version.epoch && v.major == version.major
+}
+
+// SameMinor returns true if the versions have the same major and minor
+// versions, and the same epoch for versions parsed with the WithEpoch Option.
+// False is returned if either version is nil.
+func (v *Version) SameMinor(version *Version) bool {
+	return v.SameMajor(version) && v.minor == version.minor
+}
+
+/*
+MajorDistance returns the number of major versions from the version to the
+version param, which is negative if the version param has a lower major
+version, so v1.2.3 to v3.0.0 is 2. The bool is false, and the distance 0, if
+the versions have different epochs, or either version is nil.
+*/
+func (v *Version) MajorDistance(version *Version) (int, bool) {
+	if v == nil || version == nil || v.epoch != version.epoch {
+		return 0, false
+	}
+	return distance(v.major, version.major), true
+}
+
+/*
+MinorDistance returns the number of minor versions from the version to the
+version param, which is negative if the version param has a lower minor
+version, so v1.2.3 to v1.5.0 is 3. The bool is false, and the distance 0, if
+the versions do not have the same major version.
+*/
+func (v *Version) MinorDistance(version *Version) (int, bool) {
+	if !v.SameMajor(version) {
+		return 0, false
+	}
+	return distance(v.minor, version.minor), true
+}
+
+/*
+PatchDistance returns the number of patch versions from the version to the
+version param, which is negative if the version param has a lower patch
+version, so v1.2.3 to v1.2.7 is 4. The bool is false, and the distance 0, if
+the versions do not have the same major and minor versions. Pre release and
+build metadata are ignored.
+*/
+func (v *Version) PatchDistance(version *Version) (int, bool) {
+	if !v.SameMinor(version) {
+		return 0, false
+	}
+	return distance(v.patch, version.patch), true
+}
+
+// distance returns b minus a as a signed int.
+func distance(a, b uint64) int {
+	if b >= a {
+		return int(b - a)
+	}
+	return -int(a - b)
+}
+
+/*
+BackwardCompatible returns true if the version param is a backward compatible
+replacement for the version, meaning it has equal or higher precedence and the
+same left-most non-zero version number, as with the Caret Operator.
+
+For 1.0.0 and later the major version is the breaking boundary, so v1.9.0 is
+backward compatible with v1.2.3, but v2.0.0 is not. The semver specification
+makes no compatibility promise for 0.x versions, so as with npm the minor
+version is treated as the breaking boundary, and v0.2.9 is backward compatible
+with v0.2.3, but v0.3.0 is not. For 0.0.x versions any change may break, and
+only a version of equal precedence is backward compatible. False is returned
+if either version is nil.
+*/
+func (v *Version) BackwardCompatible(version *Version) bool {
+	if v == nil || version == nil || version.Compare(v) < 0 {
+		return false
+	}
+
+	switch {
+	case v.major > 0:
+		return v.SameMajor(version)
+	case v.minor > 0:
+		return v.SameMinor(version)
+	}
+	return v.CompareCore(version) == 0
+}
+
+/*
+CompareWithMetadata checks the two versions with Compare, and when they have
+equal precedence uses the build metadata as a tiebreaker, comparing the period
+separated identifiers with the same rules as pre release data. A version
+without build metadata is lower than one with build metadata.
+
+This deviates from the https://semver.org specification, where build metadata
+is not factored into precedence, and is intended for tooling which stores
+data such as build numbers in the metadata.
+*/
+func (v *Version) CompareWithMetadata(version *Version) int {
+	if i := v.Compare(version); i != 0 || v == nil {
+		return i
+	}
+
+	switch {
+	case v.buildMetadata == version.buildMetadata:
+		return 0
+	case v.buildMetadata == "":
+		return -1
+	case version.buildMetadata == "":
+		return 1
+	}
+
+	return compareDotted(v.buildMetadata, version.buildMetadata, false)
+}
+
+/*
+comparePreRelease is an internal method that evalutes only the current version
+pre release value against the preRelease param. Similar to Compare, it returns
+1 if the current version pre release is greater than the preRelease param, -1 if
+the current version pre release is less than the preRelease param, and 0 if they
+are equal.
+
+See https://semver.org/#spec-item-11 for more details on precedence with pre
 release values.
 */
 func (v *Version) comparePreRelease(preRelease string) int {
-	if v.preRelease == "" && preRelease == "" {
+	return comparePreReleases(v.preRelease, preRelease, v.conf().foldCase)
+}
+
+/*
+CompareIdentifiers compares two raw pre release strings a and b of period
+separated identifiers, such as alpha.1 and beta, without parsing full
+versions. Similar to Compare, it returns 1 if a has higher precedence than b,
+-1 if a has lower precedence than b, and 0 if they are equal.
+
+Following https://semver.org/#spec-item-11, numeric identifiers are compared
+as integers and have lower precedence than alphanumeric identifiers, which
+are compared in ASCII sort order, and a longer set of identifiers has higher
+precedence when all of the shared identifiers are equal. An empty string is no
+pre release, which has higher precedence than any pre release.
+*/
+func CompareIdentifiers(a, b string) int {
+	return comparePreReleases(a, b, false)
+}
+
+// comparePreReleases compares the pre release strings a and b, where an empty
+// string is no pre release and has the highest precedence.
+func comparePreReleases(a, b string, fold bool) int {
+	switch {
+	case a == "" && b == "":
 		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
 	}
 
-	if v.preRelease == "" && preRelease != "" {
-		return 1
+	return compareDotted(a, b, fold)
+}
+
+/*
+comparePreReleaseParts evaluates the current version pre release against the
+pre release of the version param like comparePreRelease, using the cached pre
+release identifiers of both versions when they are available. Identifiers are
+compared case insensitively if either version uses the CaseInsensitive
+Option.
+*/
+func (v *Version) comparePreReleaseParts(version *Version) int {
+	// fold when either config folds, so the comparison is antisymmetric
+	fold := v.conf().foldCase || version.conf().foldCase
+	a, b := v.preReleaseParts, version.preReleaseParts
+	if a == nil || b == nil {
+		return comparePreReleases(v.preRelease, version.preRelease, fold)
 	}
 
-	if v.preRelease != "" && preRelease == "" {
-		return -1
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := compareIdentifier(a[i], b[i], fold); c != 0 {
+			return c
+		}
 	}
 
-	// split pre release string parts
-	vp := strings.Split(v.preRelease, ".")
-	versionp := strings.Split(preRelease, ".")
+	// every shared identifier is equal, so the longer list is higher
+	switch {
+	case len(a) > len(b):
+		return 1
+	case len(a) < len(b):
+		return -1
+	}
+	return 0
+}
 
-	// fill missing values
-	if len(vp) < len(versionp) {
-		for i := len(vp); i < len(versionp); i++ {
-			vp = append(vp, "")
+/*
+compareDotted compares two non-empty period separated lists of identifiers a
+and b field by field with compareIdentifier, and returns 1, -1, or 0. When
+every shared identifier is equal the longer list has higher precedence, so
+alpha is lower than alpha.0. The lists are walked in place without
+allocating, as this is the hot path when sorting.
+*/
+func compareDotted(a, b string, fold bool) int {
+	for {
+		x, restA, moreA := strings.Cut(a, ".")
+		y, restB, moreB := strings.Cut(b, ".")
+		if c := compareIdentifier(x, y, fold); c != 0 {
+			return c
+		}
+		switch {
+		case moreA && !moreB:
+			return 1
+		case !moreA && moreB:
+			return -1
+		case !moreA:
+			return 0
 		}
+		a, b = restA, restB
 	}
+}
 
-	if len(vp) > len(versionp) {
-		for i := len(versionp); i < len(vp); i++ {
-			versionp = append(versionp, "")
+/*
+compareIdentifier compares a single pre release identifier a against b and
+returns 1, -1, or 0. Identifiers consisting of only digits are compared
+numerically, and always have lower precedence than alphanumeric identifiers.
+Alphanumeric identifiers are compared in ASCII sort order, ignoring case when
+fold is true.
+*/
+func compareIdentifier(a, b string, fold bool) int {
+	if a == b {
+		return 0
+	}
+
+	aNum, bNum := isNumeric(a), isNumeric(b)
+	switch {
+	case aNum && bNum:
+		a = strings.TrimLeft(a, "0")
+		b = strings.TrimLeft(b, "0")
+		if len(a) != len(b) {
+			if len(a) > len(b) {
+				return 1
+			}
+			return -1
 		}
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	case fold:
+		return compareFold(a, b)
 	}
 
-	// compare all pre release parts
-	for i, v := range vp {
-		if v == versionp[i] {
-			continue
-		} else if v > versionp[i] {
-			return 1
-		} else {
+	if a > b {
+		return 1
+	}
+	if a < b {
+		return -1
+	}
+	return 0
+}
+
+// compareFold compares two ASCII strings in sort order as if both were
+// lowercase, without allocating lowercase copies.
+func compareFold(a, b string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		x, y := toLower(a[i]), toLower(b[i])
+		if x != y {
+			if x > y {
+				return 1
+			}
 			return -1
 		}
 	}
 
+	switch {
+	case len(a) > len(b):
+		return 1
+	case len(a) < len(b):
+		return -1
+	}
 	return 0
 }
 
+// toLower returns the lowercase of an ASCII letter, or c otherwise.
+func toLower(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// isNumeric returns true if s is a non-empty string of only ASCII digits.
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 /*
 Get returns a Version from the String. Strings which are not
 valid semantic versions will evaluate to v0.0.0.
+
+Get is lenient and tolerates leading zeros in numeric identifiers. Use Parse
+to distinguish an invalid String from a genuine v0.0.0.
 */
 func (v String) Get(conf ...*config) *Version {
-	set := defaultConf
-	if conf != nil && conf[0] != nil {
-		set = conf[0]
+	set := getConfig(conf)
+	ver, err := v.parse(set, false, false)
+	if err != nil {
+		return &Version{config: set}
+	}
+	return ver
+}
+
+/*
+IsValid returns true if the whole String is a valid semantic version, with an
+optional leading Operator, without allocating a Version. Each version number
+must also fit in a uint64, as with Parse.
+*/
+func (v String) IsValid(conf ...*config) bool {
+	set := getConfig(conf)
+	parts, _, err := v.match(set, false)
+	if err != nil {
+		return false
+	}
+
+	// the major, minor, and patch, then any build number and epoch
+	for i := 3; i < len(parts); i++ {
+		if (i < 6 || i > 7) && isNumeric(parts[i]) && !fitsUint64(parts[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// maxUint64 is the decimal text of the largest uint64.
+const maxUint64 = "18446744073709551615"
+
+// fitsUint64 returns true if the decimal digits of s can be parsed as a uint64.
+func fitsUint64(s string) bool {
+	for len(s) > 1 && s[0] == '0' {
+		s = s[1:]
+	}
+	return len(s) < len(maxUint64) || len(s) == len(maxUint64) && s <= maxUint64
+}
+
+/*
+Parse returns a Version from the String, or an error describing the
+invalid input if the String is not a valid semantic version.
+
+Parse is strict, and numeric identifiers in the major, minor, patch, or pre
+release parts of the version must not contain leading zeros.
+*/
+func (v String) Parse(conf ...*config) (*Version, error) {
+	return v.parse(getConfig(conf), true, false)
+}
+
+/*
+ParsePartial is Parse, but also accepts a partial version without an
+Operator, such as v1.2 or 1.x. The returned Version remembers the omitted
+version numbers, so Version.Contains treats v1.2 as any 1.2.x version, while
+Version.String writes the omitted numbers as zero, as v1.2.0.
+*/
+func (v String) ParsePartial(conf ...*config) (*Version, error) {
+	return v.parse(getConfig(conf), true, true)
+}
+
+/*
+Compare parses the String and the version String param with Get, and returns
+the result of Version.Compare, so 1 if the String is greater, -1 if it is
+lower, and 0 if they have equal precedence. As with Get an invalid String is
+treated as v0.0.0, use CompareE to detect invalid input.
+*/
+func (v String) Compare(version String, conf ...*config) int {
+	return v.Get(conf...).Compare(version.Get(conf...))
+}
+
+/*
+CompareE is Compare, but parses both strings with Parse and returns an error
+if either is not a valid semantic version.
+*/
+func (v String) CompareE(version String, conf ...*config) (int, error) {
+	a, err := v.Parse(conf...)
+	if err != nil {
+		return 0, err
 	}
+	b, err := version.Parse(conf...)
+	if err != nil {
+		return 0, err
+	}
+	return a.Compare(b), nil
+}
 
-	parts := set.re.FindStringSubmatch(string(v))
-	if len(parts) != 7 {
-		return &Version{}
+/*
+match returns the regex submatches of the String, and the number of trailing
+version numbers omitted from a partial version. Omitted version numbers are
+either missing or an x, X, or * wildcard, and must be trailing. Partial
+versions are only allowed with an operator, or in strict mode not at all,
+unless partial is true. In strict mode the parts are also checked for leading
+zeros.
+*/
+func (v String) match(set *config, partial bool) ([]string, uint8, error) {
+	parts, ok := []string(nil), false
+	if set.scan {
+		parts, ok = scanVersion(string(v))
+	}
+	if !ok {
+		parts = set.re.FindStringSubmatch(string(v))
+		if parts != nil && (set.buildNumber || set.epoch) {
+			parts = set.extendedParts(parts)
+		}
+	}
+	if len(parts) < 8 {
+		return nil, 0, fmt.Errorf("invalid semantic version: %q", string(v))
 	}
 
-	maj, _ := strconv.ParseInt(parts[2], 10, 16)
-	min, _ := strconv.ParseInt(parts[3], 10, 16)
-	patch, _ := strconv.ParseInt(parts[4], 10, 16)
+	if set.strict && parts[2] != "" {
+		return nil, 0, fmt.Errorf("invalid semantic version: %q: prefix %q is not allowed in strict mode", string(v), parts[2])
+	}
+	if set.requirePrefix && parts[2] == "" {
+		return nil, 0, fmt.Errorf("invalid semantic version: %q: missing prefix \"v\"", string(v))
+	}
+	if set.strict {
+		if err := validateParts(parts); err != nil {
+			return nil, 0, fmt.Errorf("invalid semantic version: %q: %w", string(v), err)
+		}
+	}
+
+	omitted := uint8(0)
+	for _, p := range parts[4:6] {
+		if p == "" || isWildcard(p) {
+			omitted++
+		} else if omitted > 0 {
+			return nil, 0, fmt.Errorf("invalid semantic version: %q: wildcard before version number %q", string(v), p)
+		}
+	}
+	if set.buildNumber && parts[8] != "" && omitted > 0 {
+		return nil, 0, fmt.Errorf("invalid semantic version: %q: wildcard before version number %q", string(v), parts[8])
+	}
+	if omitted > 0 && !partial && (parts[1] == "" || set.strict) {
+		name := "patch"
+		if omitted == 2 {
+			name = "minor"
+		}
+		return nil, 0, fmt.Errorf("invalid semantic version: %q: missing %s version", string(v), name)
+	}
+
+	return parts, omitted, nil
+}
+
+/*
+extendedParts reorders the regex submatches of a config with the
+WithBuildNumber or WithEpoch Option to the default submatches, followed by the
+build number and the epoch.
+*/
+func (c *config) extendedParts(parts []string) []string {
+	var build, epoch string
+	if c.epoch {
+		epoch = parts[2]
+		parts = append(parts[:2:2], parts[3:]...)
+	}
+	if c.buildNumber {
+		build = parts[6]
+		parts = append(parts[:6:6], parts[7:]...)
+	}
+	return append(parts, build, epoch)
+}
+
+// isWildcard returns true if s is an x, X, or * wildcard version number.
+func isWildcard(s string) bool {
+	return s == "x" || s == "X" || s == "*"
+}
+
+/*
+parse is the internal parser for a String. When strict is true the parsed
+parts are also validated against the https://semver.org rules the regex does
+not enforce. When partial is true a partial version is allowed without an
+operator.
+*/
+func (v String) parse(set *config, strict, partial bool) (*Version, error) {
+	parts, omitted, err := v.match(set, partial)
+	if err != nil {
+		return nil, err
+	}
+
+	// match already validates the parts in strict mode
+	if strict && !set.strict {
+		if err := validateParts(parts); err != nil {
+			return nil, fmt.Errorf("invalid semantic version: %q: %w", string(v), err)
+		}
+	}
+
+	var nums [3]uint64
+	for i, p := range parts[3 : 6-omitted] {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid semantic version: %q: %w", string(v), err)
+		}
+		nums[i] = n
+	}
+
+	preRelease := parts[6]
+	if set.lowerPreRelease {
+		preRelease = strings.ToLower(preRelease)
+	}
+
+	var build, epoch uint64
+	if set.buildNumber && parts[8] != "" {
+		if build, err = strconv.ParseUint(parts[8], 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid semantic version: %q: %w", string(v), err)
+		}
+	}
+	if set.epoch && parts[9] != "" {
+		if epoch, err = strconv.ParseUint(parts[9], 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid semantic version: %q: %w", string(v), err)
+		}
+	}
 
 	return &Version{
-		operator:      Operator(parts[1]),
-		major:         uint16(maj),
-		minor:         uint16(min),
-		patch:         uint16(patch),
-		preRelease:    parts[5],
-		buildMetadata: parts[6],
+		operator:        Operator(parts[1]),
+		epoch:           epoch,
+		major:           nums[0],
+		minor:           nums[1],
+		patch:           nums[2],
+		build:           build,
+		preRelease:      preRelease,
+		preReleaseParts: splitIdentifiers(preRelease),
+		buildMetadata:   parts[7],
+		omitted:         omitted,
+		inputPrefix:     parts[2],
+		keepPrefix:      true,
 
 		config: set,
+	}, nil
+}
+
+// splitIdentifiers returns the period separated identifiers of s, or nil if s
+// is empty.
+func splitIdentifiers(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ".")
+}
+
+/*
+validateParts checks the regex submatches of a version for leading zeros in
+the major, minor, and patch numbers, and in numeric pre release identifiers.
+Build metadata has no numeric rule and is not checked.
+*/
+func validateParts(parts []string) error {
+	for i, name := range []string{"major", "minor", "patch"} {
+		if hasLeadingZero(parts[i+3]) {
+			return fmt.Errorf("%s version %q has a %w", name, parts[i+3], ErrLeadingZero)
+		}
+	}
+
+	if parts[6] != "" {
+		for _, id := range strings.Split(parts[6], ".") {
+			if isNumeric(id) && hasLeadingZero(id) {
+				return fmt.Errorf("pre release identifier %q has a %w", id, ErrLeadingZero)
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+validateIdentifiers checks a dot separated string of pre release or build
+metadata identifiers against the https://semver.org grammar. Identifiers must
+not be empty, and can contain only ASCII alphanumeric characters and hyphens.
+When numeric is true, numeric identifiers must not contain leading zeros.
+*/
+func validateIdentifiers(s string, numeric bool) error {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return ErrEmptyIdentifier
+		}
+
+		for _, r := range id {
+			if !isIdentifierChar(r) {
+				return fmt.Errorf("identifier %q contains %w %q", id, ErrInvalidCharacter, r)
+			}
+		}
+
+		if numeric && isNumeric(id) && hasLeadingZero(id) {
+			return fmt.Errorf("identifier %q has a %w", id, ErrLeadingZero)
+		}
+	}
+
+	return nil
+}
+
+// isIdentifierChar returns true if r is valid in a pre release or build
+// metadata identifier.
+func isIdentifierChar(r rune) bool {
+	return r == '-' ||
+		(r >= '0' && r <= '9') ||
+		(r >= 'A' && r <= 'Z') ||
+		(r >= 'a' && r <= 'z')
+}
+
+// hasLeadingZero returns true if the numeric string s starts with a
+// redundant zero.
+func hasLeadingZero(s string) bool {
+	return len(s) > 1 && s[0] == '0'
+}
+
+/*
+NewVersion returns a Version from the major, minor, and patch version numbers,
+using the default config. An optional pre release and build metadata can be
+passed in that order, for example:
+
+NewVersion(1, 2, 3, "rc.1", "build.5")
+
+The pre release and build metadata are not validated, use SetPreRelease and
+SetMetadata to validate them.
+*/
+func NewVersion(major, minor, patch uint64, ext ...string) *Version {
+	v := &Version{
+		major:  major,
+		minor:  minor,
+		patch:  patch,
+		config: currentConfig(),
+	}
+	if len(ext) > 0 {
+		v.preRelease = ext[0]
+		v.preReleaseParts = splitIdentifiers(ext[0])
+	}
+	if len(ext) > 1 {
+		v.buildMetadata = ext[1]
+	}
+	return v
+}
+
+/*
+Coerce returns a Version from a possibly partial version string s, filling
+any missing minor or patch version numbers with zero, so 1 is v1.0.0 and
+2.0-beta is v2.0.0-beta. Surrounding whitespace and an optional leading "v" are
+ignored. Leading zeros are tolerated as with String.Get.
+
+An error is returned if s cannot be read as a version at all, or if it has an
+Operator or a wildcard version number, such as >=1.2 or 1.x, since those are
+ranges rather than a single version.
+*/
+func Coerce(s string) (*Version, error) {
+	s = strings.TrimSpace(s)
+	v, err := String(s).parse(currentConfig(), false, true)
+	if err != nil {
+		return nil, err
+	}
+	if v.operator != "" {
+		return nil, fmt.Errorf("invalid semantic version: %q: operator %q is not allowed", s, string(v.operator))
+	}
+	core := s
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	if strings.ContainsAny(core, "xX*") {
+		return nil, fmt.Errorf("invalid semantic version: %q: wildcard version numbers are not allowed", s)
+	}
+	v.omitted = 0
+	return v, nil
+}
+
+/*
+MustParse returns a Version from the string s, and panics with the parse
+error if s is not a valid semantic version. It is intended for package
+initialization and tests where the version string is known to be good.
+*/
+func MustParse(s string, conf ...*config) *Version {
+	v, err := String(s).Parse(conf...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+/*
+GetOrDefault returns a Version from the String s with String.Parse, or the def
+version if s is not a valid semantic version, so an invalid String can be told
+apart from a genuine v0.0.0. The def version is returned as is, and may be nil.
+*/
+func GetOrDefault(s String, def *Version, conf ...*config) *Version {
+	v, err := s.Parse(conf...)
+	if err != nil {
+		return def
 	}
+	return v
 }
@@ -3,6 +3,8 @@ package semver
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 
 	. "github.com/franela/goblin"
@@ -14,9 +16,9 @@ func TestSemverParse(t *testing.T) {
 		g.It("Should parse all semantic version parts", func() {
 			v := String(">=v1.2.3-pre+meta").Get()
 			g.Assert(v.Operator()).Equal(">=")
-			g.Assert(v.Major()).Equal(1)
-			g.Assert(v.Minor()).Equal(2)
-			g.Assert(v.Patch()).Equal(3)
+			g.Assert(v.Major()).Equal(uint64(1))
+			g.Assert(v.Minor()).Equal(uint64(2))
+			g.Assert(v.Patch()).Equal(uint64(3))
 			g.Assert(v.PreRelease()).Equal("pre")
 			g.Assert(v.Metadata()).Equal("meta")
 		})
@@ -31,21 +33,766 @@ func TestSemverParse(t *testing.T) {
 			g.Assert(string(v.ToString())).Equal(">=v1.2.3-pre+meta")
 		})
 
+		g.It("Should return pre release identifiers", func() {
+			v := String("v1.2.3-alpha.1.2").Get()
+			g.Assert(v.PreReleaseIdentifiers()).Equal([]string{"alpha", "1", "2"})
+			v = String("v1.2.3").Get()
+			g.Assert(v.PreReleaseIdentifiers()).Equal([]string{})
+		})
+
+		g.It("Should return build metadata identifiers", func() {
+			v := String("v1.0.0+exp.sha.5114f85").Get()
+			g.Assert(v.MetadataIdentifiers()).Equal([]string{"exp", "sha", "5114f85"})
+			v = String("v1.0.0-rc.1").Get()
+			g.Assert(v.MetadataIdentifiers()).Equal([]string{})
+		})
+
+		g.It("Should report a zero version", func() {
+			g.Assert(String("v0.0.0").Get().IsZero()).IsTrue()
+			g.Assert(String("nosemver").Get().IsZero()).IsTrue()
+			g.Assert(String("v0.0.1").Get().IsZero()).IsFalse()
+			g.Assert(String("v0.0.0-alpha").Get().IsZero()).IsFalse()
+			g.Assert(String("v0.0.0+build").Get().IsZero()).IsFalse()
+			g.Assert(String(">=v0.0.0").Get().IsZero()).IsFalse()
+		})
+
+		g.It("Should parse version numbers larger than 65535", func() {
+			v := String("v70000.4294967296.18446744073709551615").Get()
+			g.Assert(v.Major()).Equal(uint64(70000))
+			g.Assert(v.Minor()).Equal(uint64(4294967296))
+			g.Assert(v.Patch()).Equal(uint64(18446744073709551615))
+			g.Assert(v.String()).Equal("v70000.4294967296.18446744073709551615")
+		})
+
 		g.It("Should parse invalid semantic version to v0.0.0", func() {
 			v := String("nosemver").Get()
 			g.Assert(v.Operator()).Equal("")
-			g.Assert(v.Major()).Equal(0)
-			g.Assert(v.Minor()).Equal(0)
-			g.Assert(v.Patch()).Equal(0)
+			g.Assert(v.Major()).Equal(uint64(0))
+			g.Assert(v.Minor()).Equal(uint64(0))
+			g.Assert(v.Patch()).Equal(uint64(0))
 			g.Assert(v.PreRelease()).Equal("")
 			g.Assert(v.Metadata()).Equal("")
 		})
 	})
 }
 
+func TestParse(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("semver String strict parsing to Version", func() {
+		g.It("Should parse a valid version with an operator", func() {
+			v, err := String(">=v1.2.3-pre+meta").Parse()
+			g.Assert(err).IsNil()
+			g.Assert(v.Operator()).Equal(">=")
+			g.Assert(v.String()).Equal("v1.2.3-pre+meta")
+		})
+
+		g.It("Should return an error for malformed input", func() {
+			v, err := String("nosemver").Parse()
+			g.Assert(v).IsNil()
+			g.Assert(err.Error()).Equal(`invalid semantic version: "nosemver"`)
+		})
+
+		g.It("Should require a '+' before build metadata", func() {
+			v, err := String("v1.2.3+meta").Parse()
+			g.Assert(err).IsNil()
+			g.Assert(v.Metadata()).Equal("meta")
+
+			v, err = String("v1.2.3-rc+meta").Parse()
+			g.Assert(err).IsNil()
+			g.Assert(v.PreRelease()).Equal("rc")
+			g.Assert(v.Metadata()).Equal("meta")
+
+			v, err = String("v1.2.3meta").Parse()
+			g.Assert(v).IsNil()
+			g.Assert(err.Error()).Equal(`invalid semantic version: "v1.2.3meta"`)
+
+			_, err = String("v1.2.3.5").Parse()
+			g.Assert(err == nil).IsFalse()
+			g.Assert(String("v1.2.3meta").IsValid()).IsFalse()
+		})
+
+		g.It("Should reject empty pre release identifiers", func() {
+			for _, bad := range []string{"v1.0.0-.alpha", "v1.0.0-a..b", "v1.0.0-alpha.", "v1.0.0-a|b"} {
+				v, err := String(bad).Parse()
+				g.Assert(v == nil).IsTrue(bad)
+				g.Assert(err.Error()).Equal(fmt.Sprintf("invalid semantic version: %q", bad))
+			}
+
+			v, err := String("v1.0.0--").Parse()
+			g.Assert(err).IsNil()
+			g.Assert(v.PreRelease()).Equal("-")
+		})
+
+		g.It("Should only allow alphanumeric and hyphen identifiers", func() {
+			for _, bad := range []string{"v1.2.3+a|b", "v1.2.3+a_b", "v1.2.3-rc|1", "v1.2.3-rc_1"} {
+				v, err := String(bad).Parse()
+				g.Assert(v == nil).IsTrue(bad)
+				g.Assert(err.Error()).Equal(fmt.Sprintf("invalid semantic version: %q", bad))
+			}
+
+			v, err := String("v1.2.3-x-y-z.--+21AF26D3----117B344092BD").Parse()
+			g.Assert(err).IsNil()
+			g.Assert(v.PreRelease()).Equal("x-y-z.--")
+			g.Assert(v.Metadata()).Equal("21AF26D3----117B344092BD")
+		})
+
+		g.It("Should return an error for an empty string", func() {
+			v, err := String("").Parse()
+			g.Assert(v).IsNil()
+			g.Assert(err.Error()).Equal(`invalid semantic version: ""`)
+		})
+
+		g.It("Should return an error for version numbers overflowing uint64", func() {
+			v, err := String("v18446744073709551616.0.0").Parse()
+			g.Assert(v).IsNil()
+			g.Assert(err == nil).IsFalse()
+		})
+
+		g.It("Should reject leading zeros in numeric identifiers", func() {
+			_, err := String("v01.2.3").Parse()
+			g.Assert(err.Error()).Equal(`invalid semantic version: "v01.2.3": major version "01" has a leading zero`)
+			_, err = String("v1.02.3").Parse()
+			g.Assert(err.Error()).Equal(`invalid semantic version: "v1.02.3": minor version "02" has a leading zero`)
+			_, err = String("v1.2.03").Parse()
+			g.Assert(err.Error()).Equal(`invalid semantic version: "v1.2.03": patch version "03" has a leading zero`)
+			_, err = String("v1.2.3-alpha.01").Parse()
+			g.Assert(err.Error()).Equal(`invalid semantic version: "v1.2.3-alpha.01": pre release identifier "01" has a leading zero`)
+		})
+
+		g.It("Should allow zeros that are not leading zeros", func() {
+			v, err := String("v0.10.0-alpha.0.0a+001").Parse()
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v0.10.0-alpha.0.0a+001")
+		})
+
+		g.It("Should tolerate leading zeros with Get", func() {
+			v := String("v01.02.03-alpha.01").Get()
+			g.Assert(v.String()).Equal("v1.2.3-alpha.01")
+		})
+
+		g.It("Should only allow partial versions with an operator", func() {
+			_, err := String("1.2").Parse()
+			g.Assert(err.Error()).Equal(`invalid semantic version: "1.2": missing patch version`)
+			_, err = String("1").Parse()
+			g.Assert(err.Error()).Equal(`invalid semantic version: "1": missing minor version`)
+			v, err := String("~1.2").Parse()
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.2.0")
+			g.Assert(String("1.2").IsValid()).IsFalse()
+			g.Assert(String("~1.2").IsValid()).IsTrue()
+			g.Assert(String("~1").IsValid()).IsTrue()
+			g.Assert(String("1.x").IsValid()).IsFalse()
+			g.Assert(String("~1.x").IsValid()).IsTrue()
+		})
+
+		g.It("Should keep the input prefix with ToString", func() {
+			for _, in := range []string{"v1.2.3", "1.2.3", ">=v1.2.3-rc.1+build", "^1.2.3-beta"} {
+				g.Assert(string(String(in).Get().ToString())).Equal(in)
+			}
+			g.Assert(String("1.2.3").Get().String()).Equal("v1.2.3")
+			g.Assert(string(NewVersion(1, 2, 3).ToString())).Equal("v1.2.3")
+
+			v := String("1.2.3-rc.1").Get()
+			for _, c := range []*Version{
+				v.IncMajor(), v.IncMinor(), v.IncPatch(), v.Core(), v.FinalizeRelease(), v.Clone(),
+			} {
+				g.Assert(strings.HasPrefix(string(c.ToString()), "v")).IsFalse(string(c.ToString()))
+			}
+			p, _ := v.SetPreRelease("")
+			g.Assert(string(p.ToString())).Equal(string(v.FinalizeRelease().ToString()))
+			g.Assert(string(String("v1.2.3").Get().IncMinor().ToString())).Equal("v1.3.0")
+		})
+
+		g.It("Should return a Version from MustParse", func() {
+			v := MustParse(">=v1.2.3")
+			g.Assert(v.String()).Equal("v1.2.3")
+		})
+
+		g.It("Should panic with an error from MustParse", func() {
+			defer func() {
+				err, ok := recover().(error)
+				g.Assert(ok).IsTrue()
+				g.Assert(err.Error()).Equal(`invalid semantic version: "nosemver"`)
+			}()
+			MustParse("nosemver")
+		})
+
+		g.It("Should return the parsed version from GetOrDefault", func() {
+			def := String("v1.0.0").Get()
+			v := GetOrDefault(String(">=v1.2.3-rc.1"), def)
+			g.Assert(string(v.ToString())).Equal(">=v1.2.3-rc.1")
+			g.Assert(GetOrDefault(String("v0.0.0"), def).String()).Equal("v0.0.0")
+			g.Assert(GetOrDefault(String("1.2.3"), def, DefaultConfig(WithPrefix(""))).String()).Equal("1.2.3")
+		})
+
+		g.It("Should return the default from GetOrDefault for an invalid version", func() {
+			def := String("v1.0.0").Get()
+			g.Assert(GetOrDefault(String("nosemver"), def) == def).IsTrue()
+			g.Assert(GetOrDefault(String("1.02.3"), def) == def).IsTrue()
+			g.Assert(GetOrDefault(String(""), nil) == nil).IsTrue()
+		})
+	})
+}
+
+func TestStringCompare(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("String compare", func() {
+		g.It("Should compare two strings directly", func() {
+			g.Assert(String("v1.2.3").Compare("1.2.4")).Equal(-1)
+			g.Assert(String("1.2.4").Compare("v1.2.3")).Equal(1)
+			g.Assert(String("v1.2.3").Compare("1.2.3+build")).Equal(0)
+		})
+
+		g.It("Should treat an invalid string as v0.0.0", func() {
+			g.Assert(String("nosemver").Compare("v0.0.0")).Equal(0)
+			g.Assert(String("nosemver").Compare("v0.0.1")).Equal(-1)
+		})
+
+		g.It("Should return an error for an invalid string with CompareE", func() {
+			i, err := String("v1.2.3").CompareE("1.2.4")
+			g.Assert(err).IsNil()
+			g.Assert(i).Equal(-1)
+
+			_, err = String("v1.2.3").CompareE("nosemver")
+			g.Assert(err.Error()).Equal(`invalid semantic version: "nosemver"`)
+			_, err = String("v1.2").CompareE("1.2.4")
+			g.Assert(err.Error()).Equal(`invalid semantic version: "v1.2": missing patch version`)
+		})
+	})
+}
+
+func TestIsValid(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("semver String validation", func() {
+		g.It("Should accept versions with or without the v prefix", func() {
+			g.Assert(String("v1.2.3").IsValid()).IsTrue()
+			g.Assert(String("1.2.3").IsValid()).IsTrue()
+			g.Assert(String(">=v1.2.3-pre+meta").IsValid()).IsTrue()
+		})
+
+		g.It("Should reject a bare operator", func() {
+			g.Assert(String(">=").IsValid()).IsFalse()
+		})
+
+		g.It("Should reject surrounding whitespace", func() {
+			g.Assert(String("v1.2.3 ").IsValid()).IsFalse()
+			g.Assert(String("v1.2.3\n").IsValid()).IsFalse()
+			g.Assert(String(" v1.2.3").IsValid()).IsFalse()
+		})
+
+		g.It("Should reject version numbers which overflow a uint64", func() {
+			g.Assert(String("99999999999999999999.0.0").IsValid()).IsFalse()
+			g.Assert(String("1.18446744073709551616.0").IsValid()).IsFalse()
+			g.Assert(String("1.2.18446744073709551615").IsValid()).IsTrue()
+			g.Assert(String("1.2.3.99999999999999999999").IsValid(DefaultConfig(WithBuildNumber()))).IsFalse()
+			g.Assert(String("99999999999999999999:1.2.3").IsValid(DefaultConfig(WithEpoch()))).IsFalse()
+		})
+
+		g.It("Should use the default config for an empty config list", func() {
+			g.Assert(String("v1.2.3").IsValid([]*config{}...)).IsTrue()
+			g.Assert(String("v1.2.3").Get([]*config{}...).String()).Equal("v1.2.3")
+		})
+
+		g.It("Should use a custom config", func() {
+			conf := Config(Operators{GT: Operator("~")}, `~`)
+			g.Assert(String("~v1.2.3").IsValid(conf)).IsTrue()
+			g.Assert(String(">v1.2.3").IsValid(conf)).IsFalse()
+		})
+	})
+}
+
+func TestNewVersion(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version from integer components", func() {
+		g.It("Should return a Version", func() {
+			v := NewVersion(1, 2, 3)
+			g.Assert(v.String()).Equal("v1.2.3")
+		})
+
+		g.It("Should set an optional pre release and build metadata", func() {
+			g.Assert(NewVersion(1, 2, 3, "rc.1").String()).Equal("v1.2.3-rc.1")
+			g.Assert(NewVersion(1, 2, 3, "rc.1", "build.5").String()).Equal("v1.2.3-rc.1+build.5")
+			g.Assert(NewVersion(1, 2, 3, "", "build.5").String()).Equal("v1.2.3+build.5")
+		})
+
+		g.It("Should compare with a parsed version", func() {
+			v := NewVersion(1, 2, 3)
+			g.Assert(v.Compare(String("v1.2.3").Get())).Equal(0)
+			g.Assert(v.Compare(String("v1.2.4").Get())).Equal(-1)
+			g.Assert(String(">=v1.2.0").Get().OpCompare(v)).IsTrue()
+			g.Assert(v.OpCompare(String("v1.2.3").Get())).IsTrue()
+		})
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version normalize", func() {
+		g.It("Should normalize with and without the v prefix the same", func() {
+			g.Assert(String("1.2.3").Get().Normalize()).Equal(String("v1.2.3"))
+			g.Assert(String("1.2.3").Get().Normalize()).Equal(String("v1.2.3").Get().Normalize())
+		})
+
+		g.It("Should drop the operator and fill partial versions", func() {
+			g.Assert(String(">=1.2.3").Get().Normalize()).Equal(String("v1.2.3"))
+			g.Assert(String("~1.2").Get().Normalize()).Equal(String("v1.2.0"))
+		})
+
+		g.It("Should ignore the config prefix and drop leading zeros", func() {
+			conf := DefaultConfig(WithPrefix(""))
+			g.Assert(String("01.02.3").Get(conf).Normalize()).Equal(String("v1.2.3"))
+		})
+
+		g.It("Should keep the pre release and build metadata intact", func() {
+			g.Assert(String("1.2.3-RC.1+Build.5").Get().Normalize()).Equal(String("v1.2.3-RC.1+Build.5"))
+		})
+	})
+}
+
+func TestKey(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version precedence key", func() {
+		g.It("Should match for versions of equal precedence", func() {
+			a := String(">=v1.2.3-rc.1+build.1").Get()
+			b := String("1.2.3-rc.1+build.2").Get(DefaultConfig(WithPrefix("")))
+			g.Assert(a.Compare(b)).Equal(0)
+			g.Assert(a.Key()).Equal(b.Key())
+			g.Assert(a.Key()).Equal("v1.2.3-rc.1")
+		})
+
+		g.It("Should differ for versions of different precedence", func() {
+			g.Assert(String("v1.2.3").Get().Key() == String("v1.2.3-rc.1").Get().Key()).IsFalse()
+			g.Assert(String("v1.2.3-rc.1").Get().Key() == String("v1.2.3-rc.1.0").Get().Key()).IsFalse()
+		})
+
+		g.It("Should fold numeric leading zeros and case insensitive identifiers", func() {
+			g.Assert(String("v1.2.3-rc.01").Get().Key()).Equal("v1.2.3-rc.1")
+			g.Assert(String("v1.2.3-rc.00").Get().Key()).Equal("v1.2.3-rc.0")
+			conf := DefaultConfig(CaseInsensitive())
+			g.Assert(String("v1.2.3-RC.1").Get(conf).Key()).Equal("v1.2.3-rc.1")
+			g.Assert(String("v1.2.3-RC.1").Get().Key()).Equal("v1.2.3-RC.1")
+		})
+
+		g.It("Should work as a map key", func() {
+			seen := map[string]bool{}
+			for _, v := range getVersions("v1.0.0+a", "1.0.0+b", "v1.0.0") {
+				seen[v.Key()] = true
+			}
+			g.Assert(len(seen)).Equal(1)
+		})
+	})
+}
+
+func TestFormat(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version fmt verbs", func() {
+		v := String(">=v1.2.3-rc.1+build.5").Get()
+
+		g.It("Should print the version string for %v and %s", func() {
+			g.Assert(fmt.Sprintf("%v", v)).Equal("v1.2.3-rc.1+build.5")
+			g.Assert(fmt.Sprintf("%s", v)).Equal("v1.2.3-rc.1+build.5")
+			g.Assert(fmt.Sprint(v)).Equal("v1.2.3-rc.1+build.5")
+		})
+
+		g.It("Should include the operator for %+v", func() {
+			g.Assert(fmt.Sprintf("%+v", v)).Equal(">=v1.2.3-rc.1+build.5")
+			g.Assert(fmt.Sprintf("%+v", String("v1.0.0").Get())).Equal("v1.0.0")
+		})
+
+		g.It("Should print the fields for %#v", func() {
+			g.Assert(fmt.Sprintf("%#v", v)).Equal(
+				`semver.Version{operator:">=", epoch:0, major:1, minor:2, patch:3, build:0, preRelease:"rc.1", buildMetadata:"build.5"}`,
+			)
+			conf := DefaultConfig(WithEpoch(), WithBuildNumber())
+			g.Assert(fmt.Sprintf("%#v", String("1:2.3.4.5").Get(conf))).Equal(
+				`semver.Version{operator:"", epoch:1, major:2, minor:3, patch:4, build:5, preRelease:"", buildMetadata:""}`,
+			)
+		})
+
+		g.It("Should quote the version for %q", func() {
+			g.Assert(fmt.Sprintf("%q", v)).Equal(`"v1.2.3-rc.1+build.5"`)
+		})
+
+		g.It("Should apply width flags", func() {
+			g.Assert(fmt.Sprintf("%8s|", String("v1.0.0").Get())).Equal("  v1.0.0|")
+			g.Assert(fmt.Sprintf("%-8v|", String("v1.0.0").Get())).Equal("v1.0.0  |")
+		})
+
+		g.It("Should report an unsupported verb", func() {
+			g.Assert(fmt.Sprintf("%d", String("v1.0.0").Get())).Equal("%!d(semver.Version=v1.0.0)")
+		})
+	})
+}
+
+func TestCoerce(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Lenient partial version parsing", func() {
+		g.It("Should fill a missing minor and patch version", func() {
+			v, err := Coerce("1")
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.0.0")
+		})
+
+		g.It("Should fill a missing patch version", func() {
+			v, err := Coerce("v1.2")
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.2.0")
+		})
+
+		g.It("Should keep the pre release and build metadata", func() {
+			v, err := Coerce("2.0-beta+build.1")
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v2.0.0-beta+build.1")
+		})
+
+		g.It("Should ignore surrounding whitespace", func() {
+			v, err := Coerce("  v1.2.3\n")
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.2.3")
+		})
+
+		g.It("Should compare as a full version", func() {
+			v, _ := Coerce("1.2")
+			g.Assert(v.OpCompare(String("v1.2.0").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.2.1").Get())).IsFalse()
+		})
+
+		g.It("Should return an error for malformed input", func() {
+			v, err := Coerce("version one")
+			g.Assert(v == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`invalid semantic version: "version one"`)
+			_, err = Coerce("")
+			g.Assert(err == nil).IsFalse()
+		})
+
+		g.It("Should return an error for an operator", func() {
+			v, err := Coerce(">=1.2")
+			g.Assert(v == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`invalid semantic version: ">=1.2": operator ">=" is not allowed`)
+		})
+
+		g.It("Should return an error for a wildcard", func() {
+			v, err := Coerce("1.x")
+			g.Assert(v == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`invalid semantic version: "1.x": wildcard version numbers are not allowed`)
+			_, err = Coerce("1.2.*-beta")
+			g.Assert(err == nil).IsFalse()
+
+			v, err = Coerce("1.2-x.1")
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.2.0-x.1")
+		})
+	})
+}
+
+func TestConcurrency(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Concurrent use", func() {
+		g.It("Should parse and compare versions from multiple goroutines", func() {
+			conf := Config(Operators{GTE: Operator("+=")}, `\+=`)
+			base := String(">=v1.0.0").Get()
+
+			var wg sync.WaitGroup
+			results := make([]bool, 50)
+			for i := range results {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					v := String(fmt.Sprintf("v1.%d.0-rc.%d", i+1, i)).Get()
+					custom := String("+=v1.0.0").Get(conf)
+					results[i] = base.OpCompare(v) && custom.OpCompare(v) &&
+						v.Compare(String("v1.0.0").Get()) >= 0
+				}(i)
+			}
+			wg.Wait()
+
+			for i, ok := range results {
+				g.Assert(ok).IsTrue(fmt.Sprintf("goroutine %d", i))
+			}
+		})
+	})
+}
+
+func TestStrict(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Strict parsing mode", func() {
+		conf := DefaultConfig(Strict())
+
+		g.It("Should accept an exact semantic version", func() {
+			v, err := String("1.2.3").Parse(conf)
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.2.3")
+			v, err = String(">=1.2.3-rc.1+build").Parse(conf)
+			g.Assert(err).IsNil()
+			g.Assert(v.Operator()).Equal(">=")
+		})
+
+		g.It("Should reject the v prefix", func() {
+			_, err := String("v1.2.3").Parse(conf)
+			g.Assert(err.Error()).Equal(`invalid semantic version: "v1.2.3": prefix "v" is not allowed in strict mode`)
+			g.Assert(String("v1.2.3").IsValid(conf)).IsFalse()
+		})
+
+		g.It("Should reject partial versions", func() {
+			_, err := String("1.2").Parse(conf)
+			g.Assert(err.Error()).Equal(`invalid semantic version: "1.2": missing patch version`)
+			_, err = String("~1.2").Parse(conf)
+			g.Assert(err.Error()).Equal(`invalid semantic version: "~1.2": missing patch version`)
+		})
+
+		g.It("Should reject leading zeros", func() {
+			_, err := String("01.2.3").Parse(conf)
+			g.Assert(err.Error()).Equal(`invalid semantic version: "01.2.3": major version "01" has a leading zero`)
+			g.Assert(String("01.2.3").IsValid(conf)).IsFalse()
+			g.Assert(String("1.2.3-rc.01").IsValid(conf)).IsFalse()
+			g.Assert(String("01.2.3").Get(conf).String()).Equal("v0.0.0")
+			g.Assert(String("1.2.3-rc.1").IsValid(conf)).IsTrue()
+		})
+
+		g.It("Should allow partial versions in constraints", func() {
+			c, err := ParseConstraint("~1.2 || 2.x", conf)
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("1.2.5").Get(conf))).IsTrue()
+			g.Assert(c.Check(String("2.5.0").Get(conf))).IsTrue()
+		})
+	})
+}
+
+func TestRequirePrefix(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Required prefix mode", func() {
+		conf := DefaultConfig(RequirePrefix())
+
+		g.It("Should accept a version with the v prefix", func() {
+			v, err := String("v1.2.3").Parse(conf)
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.2.3")
+			v, err = String(">=v1.2.3-rc.1").Parse(conf)
+			g.Assert(err).IsNil()
+			g.Assert(v.Operator()).Equal(">=")
+		})
+
+		g.It("Should reject a version without the v prefix", func() {
+			v, err := String("1.2.3").Parse(conf)
+			g.Assert(v == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`invalid semantic version: "1.2.3": missing prefix "v"`)
+			g.Assert(String(">=1.2.3").IsValid(conf)).IsFalse()
+			g.Assert(String("1.2.3").Get(conf).String()).Equal("v0.0.0")
+		})
+
+		g.It("Should accept a version without the v prefix by default", func() {
+			_, err := String("1.2.3").Parse()
+			g.Assert(err).IsNil()
+		})
+
+		g.It("Should require the prefix in constraints", func() {
+			c, err := ParseConstraint(">=v1.2.0 <v2", conf)
+			g.Assert(err).IsNil()
+			g.Assert(c.String()).Equal(">=v1.2.0 <v2")
+			_, err = ParseConstraint(c.String(), conf)
+			g.Assert(err).IsNil()
+			_, err = ParseConstraint(">=v1.2.0 <2", conf)
+			g.Assert(err.Error()).Equal(`invalid constraint ">=v1.2.0 <2": invalid semantic version: "<2": missing prefix "v"`)
+		})
+	})
+}
+
 func TestConfig(t *testing.T) {
 	g := Goblin(t)
 	g.Describe("Custom config", func() {
+		g.It("Should write the v prefix by default", func() {
+			g.Assert(String("1.2.3").Get().String()).Equal("v1.2.3")
+			g.Assert(String("1.2.3").Get(DefaultConfig()).String()).Equal("v1.2.3")
+		})
+
+		g.It("Should support a custom prefix", func() {
+			conf := DefaultConfig(WithPrefix(""))
+			v := String(">=v1.2.3-rc.1").Get(conf)
+			g.Assert(v.String()).Equal("1.2.3-rc.1")
+			g.Assert(string(v.ToString())).Equal(">=v1.2.3-rc.1")
+			g.Assert(string(v.IncMinor().ToString())).Equal(">=v1.3.0")
+			b, _ := NewBuilder(conf).Major(1).Build()
+			g.Assert(string(b.ToString())).Equal("1.0.0")
+			g.Assert(String("nosemver").Get(conf).String()).Equal("0.0.0")
+
+			conf = Config(Operators{GT: Operator("+")}, `\+`, WithPrefix("V"))
+			g.Assert(String("+1.2.3").Get(conf).String()).Equal("V1.2.3")
+		})
+
+		g.It("Should compare pre release identifiers case sensitively by default", func() {
+			v := String("v1.0.0-RC.1").Get()
+			g.Assert(v.Compare(String("v1.0.0-rc.1").Get())).Equal(-1)
+		})
+
+		g.It("Should support case insensitive pre release comparison", func() {
+			conf := DefaultConfig(CaseInsensitive())
+			v := String("v1.0.0-RC.1").Get(conf)
+			g.Assert(v.Compare(String("v1.0.0-rc.1").Get())).Equal(0)
+			g.Assert(v.Compare(String("v1.0.0-rc.2").Get())).Equal(-1)
+			g.Assert(v.Compare(String("v1.0.0-beta.1").Get())).Equal(1)
+			g.Assert(v.PreRelease()).Equal("RC.1")
+		})
+
+		g.It("Should compare case insensitively in both directions", func() {
+			a := String("1.0.0-RC").Get(DefaultConfig(CaseInsensitive()))
+			b := String("1.0.0-rc").Get()
+			g.Assert(a.Compare(b)).Equal(0)
+			g.Assert(b.Compare(a)).Equal(0)
+
+			c := &Version{major: 1, preRelease: "RC"}
+			g.Assert(c.Compare(a)).Equal(0)
+			g.Assert(a.Compare(c)).Equal(0)
+
+			d := String("1.0.0-beta").Get()
+			g.Assert(a.Compare(d)).Equal(1)
+			g.Assert(d.Compare(a)).Equal(-1)
+
+			g.Assert(len(Dedup([]*Version{b, a}))).Equal(1)
+			g.Assert(len(Dedup([]*Version{a, b}))).Equal(1)
+		})
+
+		g.It("Should keep the pre release casing by default", func() {
+			v := String("v1.0.0-RC1+Build.A").Get()
+			g.Assert(v.PreRelease()).Equal("RC1")
+			g.Assert(v.Metadata()).Equal("Build.A")
+		})
+
+		g.It("Should lowercase the pre release with LowercasePrerelease", func() {
+			conf := DefaultConfig(LowercasePrerelease())
+			v := String(">=v1.0.0-RC1.Beta.10+Build.A").Get(conf)
+			g.Assert(v.PreRelease()).Equal("rc1.beta.10")
+			g.Assert(v.Metadata()).Equal("Build.A")
+			g.Assert(string(v.ToString())).Equal(">=v1.0.0-rc1.beta.10+Build.A")
+			g.Assert(v.Compare(String("v1.0.0-rc1.beta.10").Get())).Equal(0)
+
+			var versions []*Version
+			for _, s := range []string{"1.0.0-RC1", "1.0.0-rc1", "1.0.0-Rc1"} {
+				versions = append(versions, String(s).Get(conf))
+			}
+			g.Assert(len(Dedup(versions))).Equal(1)
+		})
+
+		g.It("Should parse a fourth build number with WithBuildNumber", func() {
+			conf := DefaultConfig(WithBuildNumber())
+			v, err := String("1.2.3.4").Parse(conf)
+			g.Assert(err).IsNil()
+			g.Assert(v.Patch()).Equal(uint64(3))
+			g.Assert(v.Build()).Equal(uint64(4))
+			g.Assert(v.String()).Equal("v1.2.3.4")
+
+			v = String(">=1.2.3.4-rc.1+meta").Get(conf)
+			g.Assert(v.Build()).Equal(uint64(4))
+			g.Assert(v.PreRelease()).Equal("rc.1")
+			g.Assert(v.Metadata()).Equal("meta")
+			g.Assert(v.ToString()).Equal(String(">=1.2.3.4-rc.1+meta"))
+
+			g.Assert(String("1.2.3").Get(conf).String()).Equal("v1.2.3.0")
+			g.Assert(String("1.x.x.4").IsValid(conf)).IsFalse()
+			g.Assert(String("1.2.3.4").IsValid()).IsFalse()
+			g.Assert(String("1.2.3.4").Get().Build()).Equal(uint64(0))
+		})
+
+		g.It("Should compare the build number after the patch version", func() {
+			conf := DefaultConfig(WithBuildNumber())
+			v := String("1.2.3.4").Get(conf)
+			g.Assert(v.Compare(String("1.2.3.5").Get(conf))).Equal(-1)
+			g.Assert(String("1.2.3.5").Get(conf).Compare(v)).Equal(1)
+			g.Assert(v.Compare(String("1.2.4.0").Get(conf))).Equal(-1)
+			g.Assert(v.Compare(String("1.2.3").Get(conf))).Equal(1)
+			g.Assert(String("1.2.3").Get().Compare(v)).Equal(-1)
+			g.Assert(String("1.2.3").Get().Compare(String("1.2.3.0").Get(conf))).Equal(0)
+			g.Assert(v.Diff(String("1.2.3.5").Get(conf))).Equal("build")
+			g.Assert(String(">1.2.3.4").Get(conf).OpCompare(String("1.2.3.5").Get(conf))).IsTrue()
+			g.Assert(String("~1.2.3.4").Get(conf).OpCompare(String("1.2.9").Get(conf))).IsTrue()
+			g.Assert(v.Key()).Equal("v1.2.3.4")
+		})
+
+		g.It("Should parse an epoch with WithEpoch", func() {
+			conf := DefaultConfig(WithEpoch(), WithPrefix(""))
+			v, err := String("1:2.3.4").Parse(conf)
+			g.Assert(err).IsNil()
+			g.Assert(v.Epoch()).Equal(uint64(1))
+			g.Assert(v.Major()).Equal(uint64(2))
+			g.Assert(v.String()).Equal("1:2.3.4")
+
+			v = String(">=2:1.0.0-rc.1").Get(conf)
+			g.Assert(v.Operator()).Equal(">=")
+			g.Assert(v.Epoch()).Equal(uint64(2))
+			g.Assert(v.ToString()).Equal(String(">=2:1.0.0-rc.1"))
+
+			g.Assert(String("2.3.4").Get(conf).Epoch()).Equal(uint64(0))
+			g.Assert(String("2.3.4").Get(conf).String()).Equal("2.3.4")
+			g.Assert(String("1:2.3.4").IsValid()).IsFalse()
+
+			conf = DefaultConfig(WithEpoch(), WithBuildNumber())
+			v = String("3:1.2.3.4-rc.1").Get(conf)
+			g.Assert(v.Epoch()).Equal(uint64(3))
+			g.Assert(v.Build()).Equal(uint64(4))
+			g.Assert(v.String()).Equal("3:v1.2.3.4-rc.1")
+		})
+
+		g.It("Should compare the epoch before the version", func() {
+			conf := DefaultConfig(WithEpoch())
+			v := String("1:0.1.0").Get(conf)
+			g.Assert(v.Compare(String("2.0.0").Get(conf))).Equal(1)
+			g.Assert(String("2.0.0").Get(conf).Compare(v)).Equal(-1)
+			g.Assert(v.Diff(String("2.0.0").Get(conf))).Equal("epoch")
+
+			g.Assert(String("1:1.0.0").Get(conf).Compare(String("1:2.0.0").Get(conf))).Equal(-1)
+			g.Assert(String("1:1.0.0").Get(conf).Compare(String("1:1.0.0").Get(conf))).Equal(0)
+			g.Assert(String("0:1.0.0").Get(conf).Compare(String("1.0.0").Get(conf))).Equal(0)
+
+			g.Assert(String("^1:1.2.0").Get(conf).OpCompare(String("1:1.9.0").Get(conf))).IsTrue()
+			g.Assert(String("^1:1.2.0").Get(conf).OpCompare(String("1.9.0").Get(conf))).IsFalse()
+			g.Assert(String("1:1.2.3").Get(conf).IncMinor().String()).Equal("1:v1.3.0")
+		})
+
+		g.It("Should validate well formed operators", func() {
+			conf, err := ConfigE(Operators{
+				GT:  Operator("+"),
+				GTE: Operator("+="),
+				LT:  Operator("-"),
+				LTE: Operator("-="),
+			}, `[\+|-]+=?`)
+			g.Assert(err).IsNil()
+			g.Assert(String("+=1.2.3").Get(conf).OpCompare(String("1.2.3").Get())).IsTrue()
+
+			for _, c := range []func() (*config, error){
+				func() (*config, error) { return ConfigE(defaultOps, opRe) },
+				func() (*config, error) { return ConfigE(RubyConfig().ops, `~>|[>|<]+=?|!=|=`) },
+				func() (*config, error) { return ConfigE(ComposerConfig().ops, opRe) },
+			} {
+				_, err := c()
+				g.Assert(err).IsNil()
+			}
+		})
+
+		g.It("Should return an error for ambiguous operators", func() {
+			conf, err := ConfigE(Operators{GT: Operator(">="), GTE: Operator(">=")}, `>=`)
+			g.Assert(conf == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`invalid operators: GT and GTE are both ">="`)
+		})
+
+		g.It("Should return an error for operators the regex does not match", func() {
+			_, err := ConfigE(Operators{GT: Operator(">"), GTE: Operator("=>")}, `>=?`)
+			g.Assert(err.Error()).Equal(`invalid operators: GTE "=>" is not matched by the regex`)
+		})
+
+		g.It("Should return an error for an invalid regex", func() {
+			_, err := ConfigE(Operators{GT: Operator(">")}, `(>`)
+			g.Assert(err == nil).IsFalse()
+		})
+
+		g.It("Should return an error for a regex with a capturing group", func() {
+			conf, err := ConfigE(Operators{GT: Operator(">"), GTE: Operator(">=")}, `(>)=?`)
+			g.Assert(conf == nil).IsTrue()
+			g.Assert(err.Error()).Equal(`invalid operator regex "(>)=?": capturing groups are not allowed, use (?:...)`)
+
+			conf, err = ConfigE(Operators{GT: Operator(">"), GTE: Operator(">=")}, `(?:>)=?`)
+			g.Assert(err).IsNil()
+			g.Assert(String(">=1.2.3").Get(conf).Operator()).Equal(">=")
+		})
+
 		g.It("Should support custom Operator syntax", func() {
 			conf := Config(Operators{
 				GT:  Operator("+"),
@@ -72,6 +819,63 @@ func TestConfig(t *testing.T) {
 	})
 }
 
+func TestSetDefaultConfig(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Package default config", func() {
+		g.AfterEach(func() {
+			SetDefaultConfig(nil)
+		})
+
+		g.It("Should apply custom operators without passing a config", func() {
+			SetDefaultConfig(Config(Operators{
+				GT:  Operator("+"),
+				GTE: Operator("+="),
+			}, `\+=?`, WithPrefix("")))
+
+			v := String("+=1.2.3").Get()
+			g.Assert(v.Operator()).Equal("+=")
+			g.Assert(v.String()).Equal("1.2.3")
+			g.Assert(v.OpCompare(String("1.2.4").Get())).IsTrue()
+			g.Assert(String(">=1.2.3").IsValid()).IsFalse()
+			g.Assert(NewVersion(1, 2, 3).String()).Equal("1.2.3")
+
+			c, err := ParseConstraint("+1.2.3")
+			g.Assert(err).IsNil()
+			g.Assert(c.Check(String("1.3.0").Get())).IsTrue()
+		})
+
+		g.It("Should keep the config of versions parsed before", func() {
+			v := String(">=1.2.3").Get()
+			SetDefaultConfig(DefaultConfig(WithPrefix("")))
+			g.Assert(v.String()).Equal("v1.2.3")
+			g.Assert(String("1.2.3").Get().String()).Equal("1.2.3")
+		})
+
+		g.It("Should restore the built-in default with nil", func() {
+			SetDefaultConfig(DefaultConfig(WithPrefix("")))
+			SetDefaultConfig(nil)
+			g.Assert(String(">=1.2.3").Get().String()).Equal("v1.2.3")
+		})
+
+		g.It("Should be safe to swap concurrently with parsing", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					for j := 0; j < 100; j++ {
+						if i == 0 {
+							SetDefaultConfig(DefaultConfig(WithPrefix("")))
+						}
+						String(">=1.2.3").Get().OpCompare(String("1.2.4").Get())
+					}
+				}(i)
+			}
+			wg.Wait()
+		})
+	})
+}
+
 func TestOpCompare(t *testing.T) {
 	g := Goblin(t)
 	g.Describe("Version operator compare", func() {
@@ -107,6 +911,105 @@ func TestOpCompare(t *testing.T) {
 			g.Assert(v.OpCompare(v3)).IsTrue()
 			g.Assert(v.OpCompare(v4)).IsTrue()
 		})
+		g.It("Evaluate not equal to operator", func() {
+			v := String("!=v1.2.3").Get()
+			g.Assert(v.Operator()).Equal("!=")
+			g.Assert(v.OpCompare(String("v1.2.4").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.2.3-rc.1").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.2.3").Get())).IsFalse()
+			g.Assert(v.OpCompare(String("v1.2.3+build.5").Get())).IsFalse()
+		})
+		g.It("Evaluate explicit equal to operator", func() {
+			for _, op := range []string{"=", "=="} {
+				v := String(op + "v1.2.3").Get()
+				g.Assert(v.Operator()).Equal(op)
+				g.Assert(v.OpCompare(String("v1.2.3").Get())).IsTrue()
+				g.Assert(v.OpCompare(String("v1.2.3+build.5").Get())).IsTrue()
+				g.Assert(v.OpCompare(String("v1.2.4").Get())).IsFalse()
+				g.Assert(v.OpCompare(String("v1.2.3-rc.1").Get())).IsFalse()
+			}
+			g.Assert(String("=1.2").Get().OpCompare(String("v1.2.9").Get())).IsTrue()
+			g.Assert(String("=1.2").Get().OpCompare(String("v1.3.0").Get())).IsFalse()
+			g.Assert(String("===1.2.3").IsValid()).IsFalse()
+			g.Assert(String(">=v1.2.3").Get().Operator()).Equal(">=")
+		})
+		g.It("Evaluate not equal to operator for partial versions", func() {
+			v := String("!=1.2").Get()
+			g.Assert(v.OpCompare(String("v1.1.9").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.3.0").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.2.0").Get())).IsFalse()
+			g.Assert(v.OpCompare(String("v1.2.9").Get())).IsFalse()
+		})
+		g.It("Evaluate caret operator for a 1.x version", func() {
+			v := String("^v1.2.3").Get()
+			g.Assert(v.OpCompare(String("v1.2.3").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.9.0").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.2.2").Get())).IsFalse()
+			g.Assert(v.OpCompare(String("v2.0.0-alpha").Get())).IsFalse()
+			g.Assert(v.OpCompare(String("v2.0.0").Get())).IsFalse()
+		})
+		g.It("Evaluate caret operator for a 0.x version", func() {
+			v := String("^v0.2.3").Get()
+			g.Assert(v.OpCompare(String("v0.2.3").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v0.2.9").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v0.2.2").Get())).IsFalse()
+			g.Assert(v.OpCompare(String("v0.3.0").Get())).IsFalse()
+			g.Assert(v.OpCompare(String("v1.0.0").Get())).IsFalse()
+		})
+		g.It("Evaluate caret operator for a 0.0.x version", func() {
+			v := String("^v0.0.3").Get()
+			g.Assert(v.OpCompare(String("v0.0.3").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v0.0.4").Get())).IsFalse()
+			g.Assert(v.OpCompare(String("v0.0.2").Get())).IsFalse()
+			g.Assert(v.OpCompare(String("v0.1.0").Get())).IsFalse()
+		})
+		g.It("Evaluate caret operator for partial versions", func() {
+			v := String("^1.2").Get()
+			g.Assert(v.OpCompare(String("v1.9.0").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v2.0.0").Get())).IsFalse()
+			v = String("^0.0").Get()
+			g.Assert(v.OpCompare(String("v0.0.9").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v0.1.0").Get())).IsFalse()
+			v = String("^0").Get()
+			g.Assert(v.OpCompare(String("v0.9.0").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.0.0").Get())).IsFalse()
+		})
+		g.It("Evaluate tilde operator for a full version", func() {
+			v := String("~v1.2.3").Get()
+			g.Assert(v.OpCompare(String("v1.2.3").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.2.9").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.2.2").Get())).IsFalse()
+			g.Assert(v.OpCompare(String("v1.3.0-alpha").Get())).IsFalse()
+			g.Assert(v.OpCompare(String("v1.3.0").Get())).IsFalse()
+		})
+		g.It("Evaluate tilde operator for a major and minor version", func() {
+			v := String("~1.2").Get()
+			g.Assert(v.OpCompare(String("v1.2.0").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.2.9").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.1.9").Get())).IsFalse()
+			g.Assert(v.OpCompare(String("v1.3.0").Get())).IsFalse()
+		})
+		g.It("Evaluate tilde operator for a major version", func() {
+			v := String("~1").Get()
+			g.Assert(v.OpCompare(String("v1.0.0").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.9.9").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v0.9.9").Get())).IsFalse()
+			g.Assert(v.OpCompare(String("v2.0.0").Get())).IsFalse()
+		})
+		g.It("Evaluate partial versions as wildcards", func() {
+			v := String(">1.2").Get()
+			g.Assert(v.OpCompare(String("v1.2.9").Get())).IsFalse()
+			g.Assert(v.OpCompare(String("v1.3.0").Get())).IsTrue()
+			v = String("<=1.2").Get()
+			g.Assert(v.OpCompare(String("v1.2.9").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.3.0").Get())).IsFalse()
+			v = String(">=1.2").Get()
+			g.Assert(v.OpCompare(String("v1.2.0").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.1.9").Get())).IsFalse()
+			v = String("<1.2").Get()
+			g.Assert(v.OpCompare(String("v1.1.9").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.2.0").Get())).IsFalse()
+		})
 		g.It("Evaluate equality", func() {
 			v := String("v1.0.0").Get()
 			v2 := String("v1.1.0").Get()
@@ -114,6 +1017,22 @@ func TestOpCompare(t *testing.T) {
 			g.Assert(v.OpCompare(v2)).IsFalse()
 			g.Assert(v.OpCompare(v3)).IsTrue()
 		})
+		g.It("Should match pre releases by default", func() {
+			v := String(">=v1.0.0").Get()
+			g.Assert(v.OpCompare(String("v2.0.0-beta").Get())).IsTrue()
+			g.Assert(String("^1.2.3").Get().OpCompare(String("v1.3.0-rc.1").Get())).IsTrue()
+		})
+		g.It("Should hide pre releases with the HidePrerelease option", func() {
+			conf := DefaultConfig(HidePrerelease())
+			v := String(">=v1.0.0").Get(conf)
+			g.Assert(v.OpCompare(String("v2.0.0-beta").Get())).IsFalse()
+			g.Assert(v.OpCompare(String("v2.0.0").Get())).IsTrue()
+			g.Assert(String("^1.2.3").Get(conf).OpCompare(String("v1.3.0-rc.1").Get())).IsFalse()
+
+			v = String(">=v1.0.0-alpha").Get(conf)
+			g.Assert(v.OpCompare(String("v1.0.0-beta").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.0.1-beta").Get())).IsFalse()
+		})
 		g.It("Should handle invalid comparison operator", func() {
 			v := String("~~v1.0.0").Get()
 			v2 := String("v1.1.0").Get()
@@ -122,6 +1041,36 @@ func TestOpCompare(t *testing.T) {
 	})
 }
 
+func TestOpCompareE(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version operator compare with errors", func() {
+		g.It("Should compare a known operator", func() {
+			ok, err := String("^1.2.0").Get().OpCompareE(String("1.5.0").Get())
+			g.Assert(err).IsNil()
+			g.Assert(ok).IsTrue()
+
+			ok, err = String("1.2.0").Get().OpCompareE(String("1.5.0").Get())
+			g.Assert(err).IsNil()
+			g.Assert(ok).IsFalse()
+
+			ok, err = String("==1.2.0").Get().OpCompareE(String("1.2.0").Get())
+			g.Assert(err).IsNil()
+			g.Assert(ok).IsTrue()
+		})
+
+		g.It("Should return an error for an unknown operator", func() {
+			conf := Config(Operators{Tilde: Operator("~")}, `~~|~`)
+			v := String("~~1.2.3").Get(conf)
+			g.Assert(v.Operator()).Equal("~~")
+			g.Assert(v.OpCompare(String("1.2.3").Get())).IsFalse()
+
+			ok, err := v.OpCompareE(String("1.2.3").Get())
+			g.Assert(ok).IsFalse()
+			g.Assert(err.Error()).Equal(`unknown operator "~~" for version "v1.2.3"`)
+		})
+	})
+}
+
 func TestCompare(t *testing.T) {
 	g := Goblin(t)
 
@@ -159,6 +1108,489 @@ func TestCompare(t *testing.T) {
 	})
 }
 
+func TestNilVersions(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Nil version handling", func() {
+		var none *Version
+		v := String("v0.0.0").Get()
+
+		g.It("Should compare nil lower than any version", func() {
+			g.Assert(v.Compare(nil)).Equal(1)
+			g.Assert(none.Compare(v)).Equal(-1)
+			g.Assert(none.Compare(nil)).Equal(0)
+			g.Assert(v.GreaterThan(nil)).IsTrue()
+			g.Assert(none.LessThan(v)).IsTrue()
+		})
+
+		g.It("Should return false from OpCompare", func() {
+			g.Assert(String(">=0.0.0").Get().OpCompare(nil)).IsFalse()
+			g.Assert(String("<1.0.0").Get().OpCompare(nil)).IsFalse()
+			g.Assert(none.OpCompare(v)).IsFalse()
+			ok, err := none.OpCompareE(v)
+			g.Assert(ok).IsFalse()
+			g.Assert(err).IsNil()
+		})
+
+		g.It("Should handle nil in the comparison helpers", func() {
+			g.Assert(none.Equal(nil)).IsTrue()
+			g.Assert(none.Equal(v)).IsFalse()
+			g.Assert(v.Equal(nil)).IsFalse()
+			g.Assert(none.EqualPrecedence(nil)).IsTrue()
+
+			g.Assert(none.CompareCore(nil)).Equal(0)
+			g.Assert(none.CompareCore(v)).Equal(-1)
+			g.Assert(v.CompareCore(nil)).Equal(1)
+			g.Assert(none.CompareWithMetadata(nil)).Equal(0)
+			g.Assert(v.CompareWithMetadata(nil)).Equal(1)
+
+			g.Assert(v.SameMajor(nil)).IsFalse()
+			g.Assert(none.SameMinor(v)).IsFalse()
+			g.Assert(v.BackwardCompatible(nil)).IsFalse()
+			g.Assert(none.BackwardCompatible(v)).IsFalse()
+		})
+
+		g.It("Should compare a version without a config", func() {
+			lit := &Version{operator: ">=", major: 1}
+			g.Assert(lit.OpCompare(String("v1.2.0").Get())).IsTrue()
+		})
+	})
+}
+
+func TestCompareAllocs(t *testing.T) {
+	g := Goblin(t)
+
+	g.Describe("Version compare allocations", func() {
+		g.It("Should not allocate when comparing pre releases", func() {
+			v := String("v1.0.0-alpha.beta.10").Get()
+			v2 := String("v1.0.0-alpha.beta.9").Get()
+			allocs := testing.AllocsPerRun(100, func() {
+				v.Compare(v2)
+			})
+			g.Assert(allocs).Equal(float64(0))
+
+			conf := DefaultConfig(CaseInsensitive())
+			v = String("v1.0.0-RC.1").Get(conf)
+			allocs = testing.AllocsPerRun(100, func() {
+				v.Compare(v2)
+			})
+			g.Assert(allocs).Equal(float64(0))
+		})
+	})
+}
+
+func TestPreReleaseParts(t *testing.T) {
+	g := Goblin(t)
+
+	g.Describe("Version pre release parts", func() {
+		g.It("Should be nil without a pre release", func() {
+			g.Assert(String("v1.2.3").Get().preReleaseParts == nil).IsTrue()
+			g.Assert(String("v1.2.3+build.1").Get().preReleaseParts == nil).IsTrue()
+			g.Assert(NewVersion(1, 2, 3).preReleaseParts == nil).IsTrue()
+			v, _ := String("v1.2.3-rc.1").Get().SetPreRelease("")
+			g.Assert(v.preReleaseParts == nil).IsTrue()
+			g.Assert(String("v1.2.3-rc.1").Get().IncMinor().preReleaseParts == nil).IsTrue()
+		})
+		g.It("Should be cached when the version is created", func() {
+			g.Assert(String("v1.2.3-alpha.1").Get().preReleaseParts).Equal([]string{"alpha", "1"})
+			g.Assert(NewVersion(1, 2, 3, "rc.2").preReleaseParts).Equal([]string{"rc", "2"})
+			v, _ := NewVersion(1, 2, 3).SetPreRelease("beta.3")
+			g.Assert(v.preReleaseParts).Equal([]string{"beta", "3"})
+		})
+		g.It("Should be reflected by the accessors", func() {
+			v := String("v1.2.3-alpha.1.x").Get()
+			g.Assert(v.PreRelease()).Equal("alpha.1.x")
+			g.Assert(v.PreReleaseIdentifiers()).Equal(v.preReleaseParts)
+			g.Assert(v.Clone().PreReleaseIdentifiers()).Equal([]string{"alpha", "1", "x"})
+		})
+		g.It("Should not expose the cache to modification", func() {
+			v := String("v1.2.3-alpha.1").Get()
+			ids := v.PreReleaseIdentifiers()
+			ids[0] = "beta"
+			g.Assert(v.PreReleaseIdentifiers()).Equal([]string{"alpha", "1"})
+		})
+		g.It("Should compare the same with and without the cache", func() {
+			a := String("v1.0.0-alpha.beta.10").Get()
+			b := String("v1.0.0-alpha.beta.9").Get()
+			u := &Version{major: 1, preRelease: "alpha.beta.9"}
+			g.Assert(a.Compare(b)).Equal(1)
+			g.Assert(a.Compare(u)).Equal(1)
+			g.Assert(u.Compare(a)).Equal(-1)
+			g.Assert(u.Compare(b)).Equal(0)
+			g.Assert(a.Compare(String("v1.0.0-alpha.beta").Get())).Equal(1)
+			g.Assert(a.Compare(String("v1.0.0").Get())).Equal(-1)
+		})
+	})
+}
+
+func BenchmarkComparePreRelease(b *testing.B) {
+	v := String("v1.0.0-alpha.beta.rc.10").Get()
+	v2 := String("v1.0.0-alpha.beta.rc.9").Get()
+
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			v.Compare(v2)
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		u := &Version{major: 1, preRelease: v.preRelease}
+		u2 := &Version{major: 1, preRelease: v2.preRelease}
+		for i := 0; i < b.N; i++ {
+			u.Compare(u2)
+		}
+	})
+}
+
+func TestCompareCore(t *testing.T) {
+	g := Goblin(t)
+
+	g.Describe("Version core compare", func() {
+		g.It("Should ignore pre release data", func() {
+			v := String("v1.2.3-rc").Get()
+			v2 := String("v1.2.3").Get()
+			g.Assert(v.CompareCore(v2)).Equal(0)
+			g.Assert(v.Compare(v2)).Equal(-1)
+		})
+		g.It("Should compare major, minor, and patch versions", func() {
+			v := String("v1.2.3-rc").Get()
+			g.Assert(v.CompareCore(String("v1.2.2").Get())).Equal(1)
+			g.Assert(v.CompareCore(String("v1.3.0-rc").Get())).Equal(-1)
+			g.Assert(v.CompareCore(String("v0.9.9").Get())).Equal(1)
+			g.Assert(v.CompareCore(String("v2.0.0").Get())).Equal(-1)
+		})
+	})
+}
+
+func TestCompareWithMetadata(t *testing.T) {
+	g := Goblin(t)
+
+	g.Describe("Version compare with build metadata", func() {
+		g.It("Should break a precedence tie with build metadata", func() {
+			v := String("v1.0.0+build.2").Get()
+			g.Assert(v.CompareWithMetadata(String("v1.0.0+build.10").Get())).Equal(-1)
+			g.Assert(v.CompareWithMetadata(String("v1.0.0+build.1").Get())).Equal(1)
+			g.Assert(v.CompareWithMetadata(String("v1.0.0+build.2").Get())).Equal(0)
+			g.Assert(v.Compare(String("v1.0.0+build.10").Get())).Equal(0)
+		})
+		g.It("Should order versions without build metadata first", func() {
+			v := String("v1.0.0").Get()
+			g.Assert(v.CompareWithMetadata(String("v1.0.0+1").Get())).Equal(-1)
+			g.Assert(String("v1.0.0+1").Get().CompareWithMetadata(v)).Equal(1)
+		})
+		g.It("Should compare precedence before build metadata", func() {
+			v := String("v1.0.1+1").Get()
+			g.Assert(v.CompareWithMetadata(String("v1.0.0+2").Get())).Equal(1)
+			v = String("v1.0.0-rc+2").Get()
+			g.Assert(v.CompareWithMetadata(String("v1.0.0+1").Get())).Equal(-1)
+		})
+	})
+}
+
+func TestEqual(t *testing.T) {
+	g := Goblin(t)
+
+	g.Describe("Version equality", func() {
+		g.It("Should include build metadata in Equal", func() {
+			v := String("v1.0.0+a").Get()
+			g.Assert(v.Equal(String("v1.0.0+a").Get())).IsTrue()
+			g.Assert(v.Equal(String(">=v1.0.0+a").Get())).IsTrue()
+			g.Assert(v.Equal(String("v1.0.0+b").Get())).IsFalse()
+			g.Assert(v.Equal(String("v1.0.0").Get())).IsFalse()
+			g.Assert(v.Equal(String("v1.0.0-rc+a").Get())).IsFalse()
+		})
+		g.It("Should ignore build metadata in EqualPrecedence", func() {
+			v := String("v1.0.0+a").Get()
+			g.Assert(v.EqualPrecedence(String("v1.0.0+b").Get())).IsTrue()
+			g.Assert(v.EqualPrecedence(String("v1.0.0").Get())).IsTrue()
+			g.Assert(v.EqualPrecedence(String("v1.0.0-rc+a").Get())).IsFalse()
+		})
+	})
+}
+
+func TestCompareHelpers(t *testing.T) {
+	g := Goblin(t)
+
+	g.Describe("Version comparison helpers", func() {
+		lesser := String("v1.0.0").Get()
+		equal := String("v1.1.0").Get()
+		greater := String("v1.2.0").Get()
+		v := String("v1.1.0").Get()
+
+		g.It("GreaterThan", func() {
+			g.Assert(v.GreaterThan(lesser)).IsTrue()
+			g.Assert(v.GreaterThan(equal)).IsFalse()
+			g.Assert(v.GreaterThan(greater)).IsFalse()
+		})
+		g.It("GreaterThanOrEqual", func() {
+			g.Assert(v.GreaterThanOrEqual(lesser)).IsTrue()
+			g.Assert(v.GreaterThanOrEqual(equal)).IsTrue()
+			g.Assert(v.GreaterThanOrEqual(greater)).IsFalse()
+		})
+		g.It("LessThan", func() {
+			g.Assert(v.LessThan(lesser)).IsFalse()
+			g.Assert(v.LessThan(equal)).IsFalse()
+			g.Assert(v.LessThan(greater)).IsTrue()
+		})
+		g.It("LessThanOrEqual", func() {
+			g.Assert(v.LessThanOrEqual(lesser)).IsFalse()
+			g.Assert(v.LessThanOrEqual(equal)).IsTrue()
+			g.Assert(v.LessThanOrEqual(greater)).IsTrue()
+		})
+		g.It("After", func() {
+			g.Assert(v.After(lesser)).IsTrue()
+			g.Assert(v.After(equal)).IsFalse()
+			g.Assert(v.After(greater)).IsFalse()
+		})
+		g.It("Before", func() {
+			g.Assert(v.Before(lesser)).IsFalse()
+			g.Assert(v.Before(equal)).IsFalse()
+			g.Assert(v.Before(greater)).IsTrue()
+		})
+		g.It("Should ignore build metadata with After and Before", func() {
+			meta := String("v1.1.0+build.5").Get()
+			g.Assert(v.After(meta)).IsFalse()
+			g.Assert(v.Before(meta)).IsFalse()
+			g.Assert(meta.Before(greater)).IsTrue()
+		})
+		g.It("Should respect pre release precedence", func() {
+			rc := String("v1.0.0-rc.1").Get()
+			g.Assert(rc.LessThan(lesser)).IsTrue()
+			g.Assert(rc.LessThanOrEqual(lesser)).IsTrue()
+			g.Assert(lesser.GreaterThan(rc)).IsTrue()
+			g.Assert(lesser.GreaterThanOrEqual(rc)).IsTrue()
+			g.Assert(rc.GreaterThan(String("v1.0.0-beta").Get())).IsTrue()
+			g.Assert(rc.Before(lesser)).IsTrue()
+			g.Assert(lesser.After(rc)).IsTrue()
+		})
+	})
+}
+
+func TestBetween(t *testing.T) {
+	g := Goblin(t)
+
+	g.Describe("Version inclusive range", func() {
+		low := String("v1.0.0").Get()
+		high := String("v2.0.0").Get()
+
+		g.It("Should include a version inside the range", func() {
+			g.Assert(String("v1.5.0").Get().Between(low, high)).IsTrue()
+			g.Assert(String("v2.0.0-rc.1").Get().Between(low, high)).IsTrue()
+		})
+		g.It("Should include each boundary", func() {
+			g.Assert(String("v1.0.0").Get().Between(low, high)).IsTrue()
+			g.Assert(String("v2.0.0+build").Get().Between(low, high)).IsTrue()
+		})
+		g.It("Should exclude a version outside the range", func() {
+			g.Assert(String("v0.9.9").Get().Between(low, high)).IsFalse()
+			g.Assert(String("v1.0.0-rc.1").Get().Between(low, high)).IsFalse()
+			g.Assert(String("v2.0.1").Get().Between(low, high)).IsFalse()
+		})
+		g.It("Should return false when low is greater than high", func() {
+			g.Assert(String("v1.5.0").Get().Between(high, low)).IsFalse()
+			g.Assert(String("v1.0.0").Get().Between(high, low)).IsFalse()
+		})
+	})
+}
+
+func TestDiff(t *testing.T) {
+	g := Goblin(t)
+
+	g.Describe("Version diff", func() {
+		v := String("v1.2.3-rc.1").Get()
+
+		g.It("Should report a major difference", func() {
+			g.Assert(v.Diff(String("v2.2.3-rc.1").Get())).Equal("major")
+			g.Assert(v.Diff(String("v0.0.0").Get())).Equal("major")
+		})
+		g.It("Should report a minor difference", func() {
+			g.Assert(v.Diff(String("v1.3.0").Get())).Equal("minor")
+		})
+		g.It("Should report a patch difference", func() {
+			g.Assert(v.Diff(String("v1.2.4-rc.1").Get())).Equal("patch")
+		})
+		g.It("Should report a pre release difference", func() {
+			g.Assert(v.Diff(String("v1.2.3-rc.2").Get())).Equal("prerelease")
+			g.Assert(v.Diff(String("v1.2.3").Get())).Equal("prerelease")
+		})
+		g.It("Should report no difference for equal versions", func() {
+			g.Assert(v.Diff(String("v1.2.3-rc.1+build").Get())).Equal("")
+		})
+	})
+}
+
+func TestCompatibility(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version compatibility", func() {
+		g.It("Should check for the same major and minor versions", func() {
+			v := String("v1.2.3").Get()
+			g.Assert(v.SameMajor(String("v1.9.0").Get())).IsTrue()
+			g.Assert(v.SameMajor(String("v2.2.3").Get())).IsFalse()
+			g.Assert(v.SameMinor(String("v1.2.9-rc.1").Get())).IsTrue()
+			g.Assert(v.SameMinor(String("v1.3.3").Get())).IsFalse()
+			g.Assert(v.SameMinor(String("v2.2.3").Get())).IsFalse()
+		})
+
+		g.It("Should use the major version boundary for 1.x pairs", func() {
+			v := String("v1.2.3").Get()
+			g.Assert(v.BackwardCompatible(String("v1.2.3").Get())).IsTrue()
+			g.Assert(v.BackwardCompatible(String("v1.9.0").Get())).IsTrue()
+			g.Assert(v.BackwardCompatible(String("v2.0.0").Get())).IsFalse()
+			g.Assert(v.BackwardCompatible(String("v1.2.2").Get())).IsFalse()
+		})
+
+		g.It("Should use the minor version boundary for 0.x pairs", func() {
+			v := String("v0.2.3").Get()
+			g.Assert(v.BackwardCompatible(String("v0.2.9").Get())).IsTrue()
+			g.Assert(v.BackwardCompatible(String("v0.3.0").Get())).IsFalse()
+			g.Assert(v.BackwardCompatible(String("v1.0.0").Get())).IsFalse()
+
+			v = String("v0.0.3").Get()
+			g.Assert(v.BackwardCompatible(String("v0.0.3+build").Get())).IsTrue()
+			g.Assert(v.BackwardCompatible(String("v0.0.4").Get())).IsFalse()
+		})
+	})
+}
+
+func TestParseOperator(t *testing.T) {
+	g := Goblin(t)
+
+	g.Describe("Operator parsing", func() {
+		g.It("Should parse a bare operator", func() {
+			op, ok := ParseOperator(">=")
+			g.Assert(ok).IsTrue()
+			g.Assert(op).Equal(Operator(">="))
+
+			op, ok = ParseOperator(" < ")
+			g.Assert(ok).IsTrue()
+			g.Assert(op).Equal(Operator("<"))
+		})
+
+		g.It("Should parse the operator of a version", func() {
+			for s, want := range map[string]string{">=1.2.3": ">=", "^v1.2": "^", "!= 2.0.0": "!=", "~*": "~"} {
+				op, ok := ParseOperator(s)
+				g.Assert(ok).IsTrue(s)
+				g.Assert(string(op)).Equal(want)
+			}
+		})
+
+		g.It("Should return false for an unknown or missing operator", func() {
+			for _, s := range []string{"=>", "~~", ">>1.2.3", "|", "~>", "", "1.2.3", "v1.2.3"} {
+				op, ok := ParseOperator(s)
+				g.Assert(ok).IsFalse(s)
+				g.Assert(op).Equal(Operator(""))
+			}
+		})
+
+		g.It("Should parse the operators of a config", func() {
+			op, ok := ParseOperator("~>", RubyConfig())
+			g.Assert(ok).IsTrue()
+			g.Assert(op).Equal(Operator("~>"))
+
+			_, ok = ParseOperator("^", RubyConfig())
+			g.Assert(ok).IsFalse()
+		})
+	})
+}
+
+func TestContains(t *testing.T) {
+	g := Goblin(t)
+
+	g.Describe("Partial version ranges", func() {
+		g.It("Should parse a partial version without an operator", func() {
+			v, err := String("v1.2").ParsePartial()
+			g.Assert(err).IsNil()
+			g.Assert(v.String()).Equal("v1.2.0")
+
+			_, err = String("v1.2").Parse()
+			g.Assert(err == nil).IsFalse()
+			_, err = String("v1.02").ParsePartial()
+			g.Assert(err == nil).IsFalse()
+		})
+
+		g.It("Should contain versions within a partial minor version", func() {
+			v, _ := String("1.2").ParsePartial()
+			g.Assert(v.Contains(String("v1.2.0").Get())).IsTrue()
+			g.Assert(v.Contains(String("v1.2.9").Get())).IsTrue()
+			g.Assert(v.Contains(String("v1.3.0").Get())).IsFalse()
+			g.Assert(v.Contains(String("v1.1.9").Get())).IsFalse()
+			g.Assert(v.Contains(String("v1.3.0-rc.1").Get())).IsFalse()
+		})
+
+		g.It("Should contain versions within a partial major version", func() {
+			v, _ := String("v1.x").ParsePartial()
+			g.Assert(v.Contains(String("v1.9.9").Get())).IsTrue()
+			g.Assert(v.Contains(String("v2.0.0").Get())).IsFalse()
+		})
+
+		g.It("Should only contain an equal version for a full version", func() {
+			v, _ := String("v1.2.3").ParsePartial()
+			g.Assert(v.Contains(String("v1.2.3+build").Get())).IsTrue()
+			g.Assert(v.Contains(String("v1.2.4").Get())).IsFalse()
+		})
+
+		g.It("Should ignore the operator", func() {
+			v := String(">1.2").Get()
+			g.Assert(v.Contains(String("v1.2.5").Get())).IsTrue()
+			g.Assert(v.OpCompare(String("v1.2.5").Get())).IsFalse()
+			g.Assert(v.Contains(nil)).IsFalse()
+		})
+	})
+}
+
+func TestDistance(t *testing.T) {
+	g := Goblin(t)
+
+	g.Describe("Version distance", func() {
+		v := String("v1.2.3").Get()
+
+		g.It("Should return the patch distance on the same minor version", func() {
+			d, ok := v.PatchDistance(String("v1.2.7-rc.1").Get())
+			g.Assert(ok).IsTrue()
+			g.Assert(d).Equal(4)
+
+			d, ok = v.PatchDistance(String("v1.2.0").Get())
+			g.Assert(ok).IsTrue()
+			g.Assert(d).Equal(-3)
+
+			d, ok = v.PatchDistance(String("v1.2.3+build").Get())
+			g.Assert(ok).IsTrue()
+			g.Assert(d).Equal(0)
+		})
+
+		g.It("Should return false for a patch distance across minor versions", func() {
+			d, ok := v.PatchDistance(String("v1.3.3").Get())
+			g.Assert(ok).IsFalse()
+			g.Assert(d).Equal(0)
+			_, ok = v.PatchDistance(String("v2.2.3").Get())
+			g.Assert(ok).IsFalse()
+		})
+
+		g.It("Should return the minor distance on the same major version", func() {
+			d, ok := v.MinorDistance(String("v1.5.0").Get())
+			g.Assert(ok).IsTrue()
+			g.Assert(d).Equal(3)
+
+			_, ok = v.MinorDistance(String("v2.2.3").Get())
+			g.Assert(ok).IsFalse()
+		})
+
+		g.It("Should return the major distance", func() {
+			d, ok := v.MajorDistance(String("v3.0.0").Get())
+			g.Assert(ok).IsTrue()
+			g.Assert(d).Equal(2)
+
+			d, ok = v.MajorDistance(String("v0.9.0").Get())
+			g.Assert(ok).IsTrue()
+			g.Assert(d).Equal(-1)
+		})
+
+		g.It("Should return false for versions with different epochs", func() {
+			conf := DefaultConfig(WithEpoch())
+			_, ok := String("1:1.2.3").Get(conf).MajorDistance(String("2:1.2.3").Get(conf))
+			g.Assert(ok).IsFalse()
+		})
+	})
+}
+
 func TestComparePreRelease(t *testing.T) {
 	g := Goblin(t)
 
@@ -187,6 +1619,20 @@ func TestComparePreRelease(t *testing.T) {
 			v = Version{preRelease: "1"}
 			g.Assert(v.comparePreRelease("1")).Equal(0)
 		})
+		g.It("should compare numeric identifiers as integers", func() {
+			v := Version{preRelease: "alpha.10"}
+			g.Assert(v.comparePreRelease("alpha.2")).Equal(1)
+			v = Version{preRelease: "alpha.2"}
+			g.Assert(v.comparePreRelease("alpha.10")).Equal(-1)
+			v = Version{preRelease: "10"}
+			g.Assert(v.comparePreRelease("1")).Equal(1)
+			v = Version{preRelease: "1"}
+			g.Assert(v.comparePreRelease("10")).Equal(-1)
+			v = Version{preRelease: "alpha.1"}
+			g.Assert(v.comparePreRelease("alpha.beta")).Equal(-1)
+			v = Version{preRelease: "alpha.beta"}
+			g.Assert(v.comparePreRelease("alpha.1")).Equal(1)
+		})
 		g.It("should handle dot delimited data", func() {
 			v := Version{preRelease: "alpha.2"}
 			g.Assert(v.comparePreRelease("alpha.1")).Equal(1)
@@ -205,6 +1651,19 @@ func TestComparePreRelease(t *testing.T) {
 			v := Version{preRelease: "alpha.1.1"}
 			g.Assert(v.comparePreRelease("alpha.1")).Equal(1)
 		})
+		g.It("should give a shorter set of fields lower precedence when the shared fields are equal", func() {
+			for _, c := range [][2]string{{"alpha", "alpha.0"}, {"1", "1.0"}, {"alpha", "alpha.beta"}, {"alpha.1", "alpha.1.a"}} {
+				v := Version{preRelease: c[0]}
+				g.Assert(v.comparePreRelease(c[1])).Equal(-1, c[0])
+				v = Version{preRelease: c[1]}
+				g.Assert(v.comparePreRelease(c[0])).Equal(1, c[1])
+
+				a := String("v1.0.0-" + c[0]).Get()
+				b := String("v1.0.0-" + c[1]).Get()
+				g.Assert(a.Compare(b)).Equal(-1, c[0])
+				g.Assert(b.Compare(a)).Equal(1, c[1])
+			}
+		})
 		g.It("should handle mismatched sizes and types of delimited data", func() {
 			v := Version{preRelease: "alpha.1"}
 			g.Assert(v.comparePreRelease("alpha.alpha.1")).Equal(-1)
@@ -218,6 +1677,39 @@ func TestComparePreRelease(t *testing.T) {
 	})
 }
 
+func TestCompareIdentifiers(t *testing.T) {
+	g := Goblin(t)
+
+	g.Describe("Compare raw pre release identifiers", func() {
+		g.It("Should match the pre release comparison cases", func() {
+			cases := []struct {
+				a, b string
+				want int
+			}{
+				{"", "", 0}, {"", "1", 1}, {"alpha", "", -1},
+				{"b", "a", 1}, {"a", "b", -1}, {"b", "b", 0},
+				{"2", "1", 1}, {"1", "2", -1}, {"1", "1", 0},
+				{"alpha.10", "alpha.2", 1}, {"10", "1", 1}, {"alpha.1", "alpha.beta", -1},
+				{"1", "alpha", -1}, {"beta", "5", 1},
+				{"alpha.1.1", "alpha.1", 1}, {"alpha", "alpha.0", -1}, {"1", "1.0", -1},
+				{"alpha.beta", "alpha", 1}, {"alpha.1", "alpha.alpha.1", -1}, {"rc", "alpha.1.1", 1},
+				{"RC.1", "rc.1", -1},
+			}
+			for _, c := range cases {
+				g.Assert(CompareIdentifiers(c.a, c.b)).Equal(c.want, c.a+" "+c.b)
+				g.Assert(CompareIdentifiers(c.a, c.b)).Equal((&Version{preRelease: c.a}).comparePreRelease(c.b))
+			}
+		})
+
+		g.It("Should follow the spec precedence example", func() {
+			order := []string{"alpha", "alpha.1", "alpha.beta", "beta", "beta.2", "beta.11", "rc.1", ""}
+			for i := 1; i < len(order); i++ {
+				g.Assert(CompareIdentifiers(order[i-1], order[i])).Equal(-1, order[i])
+			}
+		})
+	})
+}
+
 func Example() {
 	v := String("v3.14.15").Get()
 
@@ -268,6 +1760,24 @@ func Example_opCompare() {
 	// Output: false
 }
 
+func ExampleNewVersion() {
+	v := NewVersion(3, 14, 15, "rc.1")
+	fmt.Println(v)
+	// Output: v3.14.15-rc.1
+}
+
+func ExampleMustParse() {
+	v := MustParse("v3.14.15")
+	fmt.Println(v.Patch())
+	// Output: 15
+}
+
+func ExampleString_Parse() {
+	_, err := String("v3.14").Parse()
+	fmt.Println(err)
+	// Output: invalid semantic version: "v3.14": missing patch version
+}
+
 func ExampleString_Get() {
 	v := String(">=v3.14.15").Get()
 	fmt.Println(v.Major())
@@ -281,6 +1791,14 @@ func ExampleVersion_String() {
 	// Output: v3.14.15
 }
 
+func ExampleVersion_Normalize() {
+	fmt.Println(String("1.2.3").Get().Normalize())
+	fmt.Println(String(">=v1.2.3-rc.1").Get().Normalize())
+	// Output:
+	// v1.2.3
+	// v1.2.3-rc.1
+}
+
 func ExampleVersion() {
 	s := String("v1.2.3")
 	v := s.Get()
@@ -336,6 +1854,15 @@ func ExampleVersion_OpCompare() {
 	// Output: true
 }
 
+func ExampleVersion_OpCompare_caret() {
+	ver := String("^v1.2.3").Get()
+	fmt.Println(ver.OpCompare(String("v1.9.0").Get()))
+	fmt.Println(ver.OpCompare(String("v2.0.0").Get()))
+	// Output:
+	// true
+	// false
+}
+
 func ExampleVersion_OpCompare_equal() {
 	// By dropping any operator in the version OpCompare
 	// will produce an equality check.
@@ -359,6 +1886,15 @@ func ExampleConfig() {
 	// Output: true
 }
 
+func ExampleWithPrefix() {
+	// Write versions without the v prefix.
+	conf := DefaultConfig(WithPrefix(""))
+
+	v := String("v1.2.3").Get(conf)
+	fmt.Println(v.String())
+	// Output: 1.2.3
+}
+
 func ExampleConfig_gTEorLTE() {
 	// Support only GTE or LTE comparisons.
 	conf := Config(Operators{
@@ -0,0 +1,279 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+Versions is a slice of versions which implements sort.Interface, ordering
+versions by ascending precedence with Version.Compare. For example:
+
+sort.Sort(Versions(list))
+
+sort.Sort(sort.Reverse(Versions(list)))
+*/
+type Versions []*Version
+
+// Len returns the number of versions.
+func (vs Versions) Len() int {
+	return len(vs)
+}
+
+// Less returns true if the version at index i has lower precedence than the
+// version at index j.
+func (vs Versions) Less(i, j int) bool {
+	return vs[i].Compare(vs[j]) < 0
+}
+
+// Swap swaps the versions at index i and j.
+func (vs Versions) Swap(i, j int) {
+	vs[i], vs[j] = vs[j], vs[i]
+}
+
+// Sorted returns a sorted copy of the versions, keeping the original order of
+// versions with equal precedence as with SortStable.
+func (vs Versions) Sorted() Versions {
+	sorted := append(Versions{}, vs...)
+	SortStable(sorted)
+	return sorted
+}
+
+// Unique returns a copy of the versions with duplicates of equal precedence
+// removed, as with Dedup.
+func (vs Versions) Unique() Versions {
+	return Dedup(vs)
+}
+
+// Filter returns a copy of the versions which satisfy the constraint string,
+// as with the Filter function. An error is returned if the constraint is
+// malformed.
+func (vs Versions) Filter(constraint string, conf ...*config) (Versions, error) {
+	return Filter(vs, constraint, conf...)
+}
+
+// Max returns the version with the highest precedence, skipping nil versions,
+// or nil for an empty slice, as with the Max function.
+func (vs Versions) Max() *Version {
+	return Max(vs)
+}
+
+// Min returns the version with the lowest precedence, skipping nil versions,
+// or nil for an empty slice, as with the Min function.
+func (vs Versions) Min() *Version {
+	return Min(vs)
+}
+
+/*
+CompareFunc compares the versions a and b with Version.Compare, returning 1 if
+a is greater than b, -1 if a is less than b, and 0 if they are equal. It can
+be passed directly to slices.SortFunc.
+
+A nil version is lower than any other version, and equal to another nil.
+*/
+func CompareFunc(a, b *Version) int {
+	return a.Compare(b)
+}
+
+/*
+CompareTotal compares two versions with Version.Compare, and breaks ties of
+equal precedence by comparing the bytes of their Normalize strings, which
+include the build metadata, so v1.0.0+a is lower than v1.0.0+b. Sorting with
+CompareTotal gives the same order on every run regardless of the input order.
+
+This is NOT semantic version precedence, under which build metadata is
+ignored, and should only be used to make an order deterministic. A nil version
+is lower than any other version, and equal to another nil.
+*/
+func CompareTotal(a, b *Version) int {
+	if c := a.Compare(b); c != 0 || a == nil {
+		return c
+	}
+	return strings.Compare(string(a.Normalize()), string(b.Normalize()))
+}
+
+/*
+Sort sorts a slice of versions in ascending order of precedence, using
+Version.Compare. Build metadata does not affect the order, and the order of
+versions with equal precedence is not guaranteed. Use SortStable to keep the
+original order of equal versions.
+*/
+func Sort(versions []*Version) {
+	sort.Sort(Versions(versions))
+}
+
+/*
+SortStable sorts a slice of versions in ascending order of precedence, using
+Version.Compare, while keeping the original order of versions with equal
+precedence, such as versions which differ only by build metadata.
+*/
+func SortStable(versions []*Version) {
+	sort.Stable(Versions(versions))
+}
+
+/*
+Max returns the version with the highest precedence, or nil for an empty
+slice. Nil versions are skipped, so nil is also returned when every version is
+nil. If several versions share the highest precedence, such as versions
+which differ only by build metadata, the first is returned.
+*/
+func Max(versions []*Version) *Version {
+	var max *Version
+	for _, v := range versions {
+		if v != nil && (max == nil || v.Compare(max) > 0) {
+			max = v
+		}
+	}
+	return max
+}
+
+/*
+Min returns the version with the lowest precedence, or nil for an empty
+slice. Nil versions are skipped, so nil is also returned when every version is
+nil. If several versions share the lowest precedence, such as versions
+which differ only by build metadata, the first is returned.
+*/
+func Min(versions []*Version) *Version {
+	var min *Version
+	for _, v := range versions {
+		if v != nil && (min == nil || v.Compare(min) < 0) {
+			min = v
+		}
+	}
+	return min
+}
+
+/*
+Dedup returns the versions with duplicates of equal precedence removed,
+keeping the first occurrence of each version in the original order. As with
+Compare, build metadata is ignored, so v1.0.0+a and v1.0.0+b are duplicates.
+The versions slice is not modified.
+*/
+func Dedup(versions []*Version) []*Version {
+	return dedup(versions, func(a, b *Version) bool { return true })
+}
+
+/*
+DedupExact is Dedup, but only removes versions which also have the same build
+metadata, so v1.0.0+a and v1.0.0+b are both kept.
+*/
+func DedupExact(versions []*Version) []*Version {
+	return dedup(versions, func(a, b *Version) bool {
+		return a == nil || b == nil || a.buildMetadata == b.buildMetadata
+	})
+}
+
+/*
+dedup removes the versions of equal precedence for which same returns true
+against an earlier version. The version indexes are sorted so that versions of
+equal precedence are adjacent, in their original order.
+*/
+func dedup(versions []*Version, same func(a, b *Version) bool) []*Version {
+	order := make([]int, len(versions))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return CompareFunc(versions[order[i]], versions[order[j]]) < 0
+	})
+
+	keep := make([]bool, len(versions))
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && CompareFunc(versions[order[start]], versions[order[end]]) == 0 {
+			end++
+		}
+
+		for i := start; i < end; i++ {
+			keep[order[i]] = true
+			for _, k := range order[start:i] {
+				if keep[k] && same(versions[k], versions[order[i]]) {
+					keep[order[i]] = false
+					break
+				}
+			}
+		}
+		start = end
+	}
+
+	var unique []*Version
+	for i, v := range versions {
+		if keep[i] {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+/*
+GroupByMajor returns the versions grouped by their major version number, with
+each group sorted in ascending order of precedence with SortStable. Nil
+versions are skipped, and the versions slice is not modified.
+
+The epoch of the WithEpoch Option is not part of the key, so 2.0.0 and 1:2.3.4
+share a group, where the version with the higher epoch sorts last.
+*/
+func GroupByMajor(versions []*Version) map[uint64][]*Version {
+	groups := map[uint64][]*Version{}
+	for _, v := range versions {
+		if v != nil {
+			groups[v.major] = append(groups[v.major], v)
+		}
+	}
+	for _, group := range groups {
+		SortStable(group)
+	}
+	return groups
+}
+
+/*
+GroupByMinor returns the versions grouped by their major and minor version
+numbers, keyed by a major.minor string such as "1.2", with each group sorted
+in ascending order of precedence with SortStable. Nil versions are skipped,
+and the versions slice is not modified. As with GroupByMajor, versions of
+different epochs share a group.
+*/
+func GroupByMinor(versions []*Version) map[string][]*Version {
+	groups := map[string][]*Version{}
+	for _, v := range versions {
+		if v != nil {
+			key := strconv.FormatUint(v.major, 10) + "." + strconv.FormatUint(v.minor, 10)
+			groups[key] = append(groups[key], v)
+		}
+	}
+	for _, group := range groups {
+		SortStable(group)
+	}
+	return groups
+}
+
+/*
+UpgradePath returns the available versions a user would step through to
+upgrade from the current version to the target version, which are the
+versions with higher precedence than current, and lower or equal precedence
+to target, sorted in ascending order with SortStable. The available slice is
+not modified.
+
+An error is returned if either version is nil, or the target has lower
+precedence than the current version.
+*/
+func UpgradePath(current, target *Version, available []*Version) ([]*Version, error) {
+	if current == nil || target == nil {
+		return nil, errors.New("cannot build an upgrade path with a nil version")
+	}
+	if target.Compare(current) < 0 {
+		return nil, fmt.Errorf("target version %q is lower than current version %q", target.String(), current.String())
+	}
+
+	var path []*Version
+	for _, v := range available {
+		if v != nil && v.Compare(current) > 0 && v.Compare(target) <= 0 {
+			path = append(path, v)
+		}
+	}
+	SortStable(path)
+	return path, nil
+}
@@ -0,0 +1,386 @@
+package semver
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+
+	. "github.com/franela/goblin"
+)
+
+// versionStrings returns the String form of each version.
+func versionStrings(versions []*Version) []string {
+	s := make([]string, len(versions))
+	for i, v := range versions {
+		s[i] = v.String()
+	}
+	return s
+}
+
+// getVersions parses each string with String.Get.
+func getVersions(s ...string) []*Version {
+	versions := make([]*Version, len(s))
+	for i, v := range s {
+		versions[i] = String(v).Get()
+	}
+	return versions
+}
+
+func TestSort(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Sorting versions", func() {
+		g.It("Should sort a shuffled slice in ascending order", func() {
+			versions := getVersions(
+				"v1.0.0", "v2.0.0-rc.1", "v0.9.0", "v1.0.0-alpha",
+				"v2.0.0", "v1.0.0-alpha.10", "v1.0.0-alpha.2", "v1.10.0",
+			)
+			Sort(versions)
+			g.Assert(versionStrings(versions)).Equal([]string{
+				"v0.9.0", "v1.0.0-alpha", "v1.0.0-alpha.2", "v1.0.0-alpha.10",
+				"v1.0.0", "v1.10.0", "v2.0.0-rc.1", "v2.0.0",
+			})
+		})
+
+		g.It("Should keep the order of equal versions with SortStable", func() {
+			versions := getVersions(
+				"v1.0.0+b", "v0.1.0", "v1.0.0+a", "v1.0.0+c", "v0.2.0",
+			)
+			SortStable(versions)
+			g.Assert(versionStrings(versions)).Equal([]string{
+				"v0.1.0", "v0.2.0", "v1.0.0+b", "v1.0.0+a", "v1.0.0+c",
+			})
+		})
+	})
+}
+
+func TestVersions(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Versions sort.Interface", func() {
+		g.It("Should sort in descending order with sort.Reverse", func() {
+			versions := getVersions("v1.0.0", "v3.0.0", "v2.0.0", "v2.1.0")
+			sort.Sort(sort.Reverse(Versions(versions)))
+			g.Assert(versionStrings(versions)).Equal([]string{
+				"v3.0.0", "v2.1.0", "v2.0.0", "v1.0.0",
+			})
+		})
+
+		g.It("Should sort pre releases before their stable version", func() {
+			versions := getVersions("v1.0.0", "v1.0.0-rc.1", "v0.9.0", "v1.0.0-beta")
+			sort.Sort(Versions(versions))
+			g.Assert(versionStrings(versions)).Equal([]string{
+				"v0.9.0", "v1.0.0-beta", "v1.0.0-rc.1", "v1.0.0",
+			})
+		})
+	})
+}
+
+func TestVersionsChain(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Versions chainable helpers", func() {
+		vs := Versions(getVersions("v2.0.0", "v1.2.0+b", "v1.0.0", "v1.2.0+a", "v1.5.0", "v3.0.0"))
+
+		g.It("Should chain sort, unique, and filter", func() {
+			out, err := vs.Sorted().Unique().Filter("^1.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(versionStrings(out)).Equal([]string{"v1.0.0", "v1.2.0+b", "v1.5.0"})
+		})
+
+		g.It("Should not modify the receiver", func() {
+			vs.Sorted()
+			vs.Unique()
+			_, _ = vs.Filter("^1.0.0")
+			g.Assert(versionStrings(vs)).Equal([]string{
+				"v2.0.0", "v1.2.0+b", "v1.0.0", "v1.2.0+a", "v1.5.0", "v3.0.0",
+			})
+		})
+
+		g.It("Should return the max and min of a filtered list", func() {
+			out, err := vs.Filter("^1.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(out.Max().String()).Equal("v1.5.0")
+			g.Assert(out.Min().String()).Equal("v1.0.0")
+
+			out, err = vs.Filter("~1.2")
+			g.Assert(err).IsNil()
+			g.Assert(out.Max().String()).Equal("v1.2.0+b")
+			g.Assert(out.Min().String()).Equal("v1.2.0+b")
+		})
+
+		g.It("Should return nil max and min for an empty list", func() {
+			out, err := vs.Filter(">=4.0.0")
+			g.Assert(err).IsNil()
+			g.Assert(out.Max() == nil).IsTrue()
+			g.Assert(out.Min() == nil).IsTrue()
+		})
+
+		g.It("Should return an error for a malformed constraint", func() {
+			out, err := vs.Sorted().Filter("^one")
+			g.Assert(out == nil).IsTrue()
+			g.Assert(err == nil).IsFalse()
+		})
+	})
+}
+
+func TestCompareFunc(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("CompareFunc", func() {
+		g.It("Should sort with slices.SortFunc", func() {
+			versions := getVersions("v1.0.0", "v0.1.0", "v1.0.0-rc.1", "v0.10.0")
+			slices.SortFunc(versions, CompareFunc)
+			g.Assert(versionStrings(versions)).Equal([]string{
+				"v0.1.0", "v0.10.0", "v1.0.0-rc.1", "v1.0.0",
+			})
+		})
+
+		g.It("Should treat nil as lower than any version", func() {
+			v := String("v0.0.0").Get()
+			g.Assert(CompareFunc(nil, v)).Equal(-1)
+			g.Assert(CompareFunc(v, nil)).Equal(1)
+			g.Assert(CompareFunc(nil, nil)).Equal(0)
+
+			versions := []*Version{v, nil, String("v1.0.0").Get()}
+			slices.SortFunc(versions, CompareFunc)
+			g.Assert(versions[0] == nil).IsTrue()
+			g.Assert(versions[2].String()).Equal("v1.0.0")
+		})
+	})
+}
+
+func TestCompareTotal(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("CompareTotal", func() {
+		g.It("Should order by precedence first", func() {
+			g.Assert(CompareTotal(String("v1.0.0+z").Get(), String("v1.0.1+a").Get())).Equal(-1)
+			g.Assert(CompareTotal(String("v1.0.0").Get(), String("v1.0.0-rc.1+z").Get())).Equal(1)
+			g.Assert(CompareTotal(nil, String("v0.0.0").Get())).Equal(-1)
+			g.Assert(CompareTotal(nil, nil)).Equal(0)
+		})
+
+		g.It("Should break ties with the build metadata", func() {
+			a := String("v1.0.0+build.2").Get()
+			b := String("1.0.0+build.10").Get()
+			g.Assert(CompareTotal(a, b)).Equal(1)
+			g.Assert(CompareTotal(b, a)).Equal(-1)
+			g.Assert(CompareTotal(a, String("v1.0.0+build.2").Get())).Equal(0)
+		})
+
+		g.It("Should sort deterministically regardless of input order", func() {
+			want := []string{"v1.0.0-rc.1", "v1.0.0", "v1.0.0+a", "v1.0.0+b", "v1.1.0"}
+			for _, order := range [][]string{
+				{"v1.0.0+b", "v1.1.0", "v1.0.0", "v1.0.0+a", "v1.0.0-rc.1"},
+				{"v1.0.0+a", "v1.0.0-rc.1", "v1.0.0+b", "v1.0.0", "v1.1.0"},
+			} {
+				versions := getVersions(order...)
+				slices.SortFunc(versions, CompareTotal)
+				g.Assert(versionStrings(versions)).Equal(want)
+			}
+		})
+	})
+}
+
+func TestMinMax(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Min and Max versions", func() {
+		versions := getVersions(
+			"v1.0.0-rc.1", "v1.0.0+a", "v2.0.0-beta", "v1.0.0+b", "v0.9.0-rc.1", "v0.9.0",
+		)
+
+		g.It("Should return the highest version", func() {
+			g.Assert(Max(versions).String()).Equal("v2.0.0-beta")
+		})
+
+		g.It("Should return the lowest version", func() {
+			g.Assert(Min(versions).String()).Equal("v0.9.0-rc.1")
+		})
+
+		g.It("Should return the first of equal versions", func() {
+			ties := getVersions("v1.0.0+a", "v0.1.0", "v1.0.0+b")
+			g.Assert(Max(ties).String()).Equal("v1.0.0+a")
+			ties = getVersions("v1.0.0+b", "v1.0.0+a")
+			g.Assert(Min(ties).String()).Equal("v1.0.0+b")
+		})
+
+		g.It("Should return nil for an empty slice", func() {
+			g.Assert(Max(nil) == nil).IsTrue()
+			g.Assert(Min([]*Version{}) == nil).IsTrue()
+		})
+
+		g.It("Should skip nil versions", func() {
+			versions := []*Version{String("v1.0.0").Get(), nil, String("v2.0.0").Get()}
+			g.Assert(Min(versions).String()).Equal("v1.0.0")
+			g.Assert(Max(versions).String()).Equal("v2.0.0")
+			g.Assert(Versions(versions).Min().String()).Equal("v1.0.0")
+
+			versions = []*Version{nil, String("v2.0.0").Get(), String("v1.0.0").Get()}
+			g.Assert(Min(versions).String()).Equal("v1.0.0")
+			g.Assert(Max([]*Version{nil, nil}) == nil).IsTrue()
+		})
+	})
+}
+
+// benchVersions returns n versions with a mix of stable and pre releases.
+func TestDedup(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version slice dedup", func() {
+		versions := getVersions(
+			"v1.0.0+a", "v2.0.0", "1.0.0+b", "v1.0.0-rc.1", "v2.0.0", "v1.0.0+a", "v1.0.0",
+		)
+
+		g.It("Should remove versions of equal precedence", func() {
+			g.Assert(versionStrings(Dedup(versions))).Equal([]string{"v1.0.0+a", "v2.0.0", "v1.0.0-rc.1"})
+		})
+
+		g.It("Should keep build metadata distinctions", func() {
+			g.Assert(versionStrings(DedupExact(versions))).Equal([]string{
+				"v1.0.0+a", "v2.0.0", "v1.0.0+b", "v1.0.0-rc.1", "v1.0.0",
+			})
+		})
+
+		g.It("Should not modify the original slice", func() {
+			Dedup(versions)
+			g.Assert(len(versions)).Equal(7)
+			g.Assert(versions[2].String()).Equal("v1.0.0+b")
+		})
+
+		g.It("Should handle empty and nil entries", func() {
+			g.Assert(len(Dedup(nil))).Equal(0)
+			d := Dedup([]*Version{nil, String("v1.0.0").Get(), nil})
+			g.Assert(len(d)).Equal(2)
+			g.Assert(d[0] == nil).IsTrue()
+		})
+	})
+}
+
+func TestGroup(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Grouping versions", func() {
+		versions := getVersions(
+			"v2.1.0", "v1.2.3", "v0.9.0", "v1.0.0", "v2.0.0-rc.1", "v1.2.0", "v2.0.0",
+		)
+
+		g.It("Should group by major version", func() {
+			groups := GroupByMajor(append(versions, nil))
+			g.Assert(len(groups)).Equal(3)
+			g.Assert(versionStrings(groups[0])).Equal([]string{"v0.9.0"})
+			g.Assert(versionStrings(groups[1])).Equal([]string{"v1.0.0", "v1.2.0", "v1.2.3"})
+			g.Assert(versionStrings(groups[2])).Equal([]string{"v2.0.0-rc.1", "v2.0.0", "v2.1.0"})
+		})
+
+		g.It("Should group by major and minor version", func() {
+			groups := GroupByMinor(versions)
+			g.Assert(len(groups)).Equal(5)
+			g.Assert(versionStrings(groups["0.9"])).Equal([]string{"v0.9.0"})
+			g.Assert(versionStrings(groups["1.0"])).Equal([]string{"v1.0.0"})
+			g.Assert(versionStrings(groups["1.2"])).Equal([]string{"v1.2.0", "v1.2.3"})
+			g.Assert(versionStrings(groups["2.0"])).Equal([]string{"v2.0.0-rc.1", "v2.0.0"})
+			g.Assert(versionStrings(groups["2.1"])).Equal([]string{"v2.1.0"})
+		})
+
+		g.It("Should group versions of different epochs together", func() {
+			conf := DefaultConfig(WithEpoch())
+			groups := GroupByMajor([]*Version{String("1:2.3.4").Get(conf), String("2.0.0").Get(conf)})
+			g.Assert(len(groups)).Equal(1)
+			g.Assert(versionStrings(groups[2])).Equal([]string{"v2.0.0", "1:v2.3.4"})
+		})
+
+		g.It("Should not modify the original slice", func() {
+			GroupByMajor(versions)
+			GroupByMinor(versions)
+			g.Assert(versions[0].String()).Equal("v2.1.0")
+			g.Assert(versions[1].String()).Equal("v1.2.3")
+		})
+
+		g.It("Should return an empty map for no versions", func() {
+			g.Assert(len(GroupByMajor(nil))).Equal(0)
+			g.Assert(len(GroupByMinor(nil))).Equal(0)
+		})
+	})
+}
+
+func TestUpgradePath(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version upgrade path", func() {
+		available := getVersions(
+			"v1.4.0", "v1.2.0", "v1.2.1", "v1.3.0-rc.1", "v1.3.0", "v2.0.0-beta.1", "v1.5.2",
+			"v2.0.0", "v1.1.0", "v2.1.0",
+		)
+
+		g.It("Should list the versions after current up to the target", func() {
+			path, err := UpgradePath(String("v1.2.0").Get(), String("v2.0.0").Get(), available)
+			g.Assert(err).IsNil()
+			g.Assert(versionStrings(path)).Equal([]string{
+				"v1.2.1", "v1.3.0-rc.1", "v1.3.0", "v1.4.0", "v1.5.2", "v2.0.0-beta.1", "v2.0.0",
+			})
+		})
+
+		g.It("Should include a target which is not available", func() {
+			path, err := UpgradePath(String("v1.3.0").Get(), String("v1.5.0").Get(), available)
+			g.Assert(err).IsNil()
+			g.Assert(versionStrings(path)).Equal([]string{"v1.4.0"})
+		})
+
+		g.It("Should return an empty path for the same version", func() {
+			path, err := UpgradePath(String("v1.3.0").Get(), String("v1.3.0+build").Get(), available)
+			g.Assert(err).IsNil()
+			g.Assert(len(path)).Equal(0)
+		})
+
+		g.It("Should return an error for a target lower than current", func() {
+			_, err := UpgradePath(String("v2.0.0").Get(), String("v1.2.0").Get(), available)
+			g.Assert(err.Error()).Equal(`target version "v1.2.0" is lower than current version "v2.0.0"`)
+
+			_, err = UpgradePath(nil, String("v1.2.0").Get(), available)
+			g.Assert(err.Error()).Equal("cannot build an upgrade path with a nil version")
+		})
+
+		g.It("Should not modify the available slice", func() {
+			UpgradePath(String("v1.0.0").Get(), String("v3.0.0").Get(), available)
+			g.Assert(available[0].String()).Equal("v1.4.0")
+		})
+	})
+}
+
+func benchVersions(n int) []*Version {
+	pre := []string{"", "alpha", "alpha.1", "beta.2", "rc.1", "rc.10"}
+	versions := make([]*Version, n)
+	for i := range versions {
+		versions[i] = NewVersion(uint64(i%3), uint64(i%7), uint64(i%5), pre[i%len(pre)])
+	}
+	return versions
+}
+
+func BenchmarkSort(b *testing.B) {
+	versions := benchVersions(10000)
+	list := make([]*Version, len(versions))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(list, versions)
+		Sort(list)
+	}
+}
+
+func ExampleSort() {
+	versions := []*Version{
+		String("v1.2.0").Get(),
+		String("v1.0.0").Get(),
+		String("v1.2.0-rc.1").Get(),
+	}
+	Sort(versions)
+
+	fmt.Println(versions)
+	// Output: [v1.0.0 v1.2.0-rc.1 v1.2.0]
+}
+
+func ExampleVersions() {
+	versions := []*Version{
+		String("v1.0.0").Get(),
+		String("v1.2.0").Get(),
+		String("v1.1.0").Get(),
+	}
+	sort.Sort(sort.Reverse(Versions(versions)))
+
+	fmt.Println(versions)
+	// Output: [v1.2.0 v1.1.0 v1.0.0]
+}
@@ -0,0 +1,124 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Errors for the categories of https://semver.org violations reported by
+// Validate, which can be checked with errors.Is.
+var (
+	// ErrMissingVersion is a missing major, minor, or patch version number.
+	ErrMissingVersion = errors.New("missing version number")
+	// ErrLeadingZero is a numeric identifier with a leading zero.
+	ErrLeadingZero = errors.New("leading zero")
+	// ErrInvalidCharacter is a character which is not allowed in its part of
+	// the version, such as an operator, wildcard, or underscore.
+	ErrInvalidCharacter = errors.New("invalid character")
+	// ErrEmptyIdentifier is an empty pre release or build metadata
+	// identifier.
+	ErrEmptyIdentifier = errors.New("empty identifier")
+	// ErrOverflow is a version number which does not fit in a uint64.
+	ErrOverflow = errors.New("version number overflows uint64")
+)
+
+/*
+ValidationError is the error returned by Validate, holding the validated input
+and the first violation found, which wraps one of the category errors such as
+ErrLeadingZero.
+*/
+type ValidationError struct {
+	// Input is the validated version string.
+	Input string
+	// Err is the first violation in the version string.
+	Err error
+}
+
+// Error returns the violation with the input.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid semantic version: %q: %v", e.Input, e.Err)
+}
+
+// Unwrap returns the violation, so the category can be checked with
+// errors.Is.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+/*
+Validate checks the version string s against the https://semver.org grammar,
+and returns a *ValidationError describing the first violation, or nil if s is
+a valid semantic version. The leading "v" is allowed, but an Operator or a
+partial version is not. The category of the violation can be checked with
+errors.Is, for example:
+
+errors.Is(semver.Validate("1.02.3"), semver.ErrLeadingZero)
+*/
+func Validate(s string) error {
+	if err := validate(s); err != nil {
+		return &ValidationError{Input: s, Err: err}
+	}
+	return nil
+}
+
+// validate returns the first violation in the version string s.
+func validate(s string) error {
+	i := 0
+	if strings.HasPrefix(s, "v") {
+		i++
+	}
+
+	for n, name := range []string{"major", "minor", "patch"} {
+		if n > 0 {
+			if i == len(s) {
+				return fmt.Errorf("%w: %s", ErrMissingVersion, name)
+			}
+			if s[i] != '.' {
+				return fmt.Errorf("%s version contains %w %q", name, ErrInvalidCharacter, rune(s[i]))
+			}
+			i++
+		}
+
+		j := scanDigits(s, i)
+		if j == i {
+			if i == len(s) || s[i] == '.' {
+				return fmt.Errorf("%w: %s", ErrMissingVersion, name)
+			}
+			return fmt.Errorf("%s version contains %w %q", name, ErrInvalidCharacter, rune(s[i]))
+		}
+
+		num := s[i:j]
+		if hasLeadingZero(num) {
+			return fmt.Errorf("%s version %q has a %w", name, num, ErrLeadingZero)
+		}
+		if _, err := strconv.ParseUint(num, 10, 64); err != nil {
+			return fmt.Errorf("%s version %q: %w", name, num, ErrOverflow)
+		}
+		i = j
+	}
+
+	rest := s[i:]
+	if rest == "" {
+		return nil
+	}
+
+	pre, meta, hasMeta := strings.Cut(rest, "+")
+	switch {
+	case pre == "":
+	case pre[0] == '-':
+		if err := validateIdentifiers(pre[1:], true); err != nil {
+			return fmt.Errorf("invalid pre release: %w", err)
+		}
+	default:
+		return fmt.Errorf("patch version contains %w %q", ErrInvalidCharacter, rune(pre[0]))
+	}
+
+	if hasMeta {
+		if err := validateIdentifiers(meta, false); err != nil {
+			return fmt.Errorf("invalid build metadata: %w", err)
+		}
+	}
+	return nil
+}
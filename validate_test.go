@@ -0,0 +1,76 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/franela/goblin"
+)
+
+func TestValidate(t *testing.T) {
+	g := Goblin(t)
+	g.Describe("Version string validation", func() {
+		g.It("Should accept valid versions", func() {
+			for _, s := range []string{
+				"1.2.3", "v1.2.3", "0.0.0", "1.0.0-alpha.1", "1.0.0-0.3.7",
+				"1.0.0-x-y-z.--", "1.0.0+20130313144700", "1.0.0-beta+exp.sha.5114f85",
+				"1.0.0+001",
+			} {
+				g.Assert(Validate(s)).IsNil(s)
+			}
+		})
+
+		g.It("Should report a missing version number", func() {
+			for _, s := range []string{"", "v", "1", "1.2", "1..3", "1.2."} {
+				err := Validate(s)
+				g.Assert(errors.Is(err, ErrMissingVersion)).IsTrue(s)
+			}
+			g.Assert(Validate("1.2").Error()).Equal(`invalid semantic version: "1.2": missing version number: patch`)
+		})
+
+		g.It("Should report a leading zero", func() {
+			for _, s := range []string{"01.2.3", "1.02.3", "1.2.03", "1.2.3-rc.01"} {
+				g.Assert(errors.Is(Validate(s), ErrLeadingZero)).IsTrue(s)
+			}
+			g.Assert(Validate("1.2.3-rc.01").Error()).Equal(
+				`invalid semantic version: "1.2.3-rc.01": invalid pre release: identifier "01" has a leading zero`,
+			)
+			g.Assert(Validate("1.2.3+01")).IsNil()
+		})
+
+		g.It("Should report an invalid character", func() {
+			for _, s := range []string{">=1.2.3", "1.x.3", "1.2.3_rc", "1.2.3-rc_1", "1.2.3+a|b", "1.2.3+a+b", "1.2.3.4"} {
+				g.Assert(errors.Is(Validate(s), ErrInvalidCharacter)).IsTrue(s)
+			}
+			g.Assert(Validate("1.2.3-rc_1").Error()).Equal(
+				`invalid semantic version: "1.2.3-rc_1": invalid pre release: identifier "rc_1" contains invalid character '_'`,
+			)
+		})
+
+		g.It("Should report an empty identifier", func() {
+			for _, s := range []string{"1.2.3-", "1.2.3-a..b", "1.2.3+", "1.2.3-.a", "1.2.3-rc+"} {
+				g.Assert(errors.Is(Validate(s), ErrEmptyIdentifier)).IsTrue(s)
+			}
+		})
+
+		g.It("Should report an overflowing version number", func() {
+			g.Assert(errors.Is(Validate("18446744073709551616.0.0"), ErrOverflow)).IsTrue()
+		})
+
+		g.It("Should return a ValidationError", func() {
+			var verr *ValidationError
+			g.Assert(errors.As(Validate("1.2"), &verr)).IsTrue()
+			g.Assert(verr.Input).Equal("1.2")
+		})
+	})
+}
+
+func ExampleValidate() {
+	err := Validate("1.02.3")
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, ErrLeadingZero))
+	// Output:
+	// invalid semantic version: "1.02.3": minor version "02" has a leading zero
+	// true
+}